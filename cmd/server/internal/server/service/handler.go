@@ -160,6 +160,15 @@ func (h *Handler) EnterRoom(user *User, roomID int64) (*Room, error) {
 	return room, nil
 }
 
+// PickTeam lets user join a team in the room the user entered.
+func (h *Handler) PickTeam(user *User, team int) error {
+	room := user.roomInfo.room.Load()
+	if room == nil {
+		return fmt.Errorf("user[%s] is not in any room", user.id)
+	}
+	return room.PickTeam(user, team)
+}
+
 func (h *Handler) Warning(msg string, args ...any) {}
 func (h *Handler) ThrowError(err error) {
 	// TODO implement me
@@ -69,6 +69,7 @@ func (r *Room) Enter(user *User) error {
 	for i, player := range players {
 		if player == nil {
 			players[i] = user
+			user.team.Store(-1)
 			userRoomInfo.isSpectator.Store(false)
 			return nil
 		}
@@ -76,6 +77,41 @@ func (r *Room) Enter(user *User) error {
 	return r.addToSpectators(user)
 }
 
+// PickTeam lets a player of the room join a team of a team mode.
+func (r *Room) PickTeam(user *User, team int) error {
+	r.Lock()
+	defer r.Unlock()
+	mode := *r.mode.Load()
+	teams := mode.GetTeamCount()
+	if teams <= 0 {
+		return fmt.Errorf("mode %s has no teams", mode.GetName())
+	}
+	if team < 0 || team >= teams {
+		return fmt.Errorf("no such team: %d", team)
+	}
+	_, maxPlayer := mode.GetPlayerCountLimit()
+	var joined bool
+	members := 0
+	for _, player := range *r.users.Load() {
+		if player == nil {
+			continue
+		}
+		if player.id == user.id {
+			joined = true
+		} else if player.Team() == team {
+			members++
+		}
+	}
+	if !joined {
+		return fmt.Errorf("user[%s] is not a player of room: %d", user.id, r.id)
+	}
+	if members >= maxPlayer/teams {
+		return fmt.Errorf("team %d is full", team)
+	}
+	user.team.Store(int64(team))
+	return nil
+}
+
 func (r *Room) Rename(v string) {
 	r.name.Store(&v)
 }
@@ -106,6 +142,7 @@ func (r *Room) Leave(user *User) error {
 	for i, player := range players { // remove user from users
 		if player != nil && player.id == user.id {
 			players[i] = nil
+			user.team.Store(-1)
 			break
 		}
 	}
@@ -140,6 +177,11 @@ func (r *Room) SetMode(user *User, modeName string) (err error) {
 	defer r.mode.Store(&mode)
 	r.resetConfigForMode(mode)
 	players := *r.users.Load()
+	for _, player := range players {
+		if player != nil {
+			player.team.Store(-1)
+		}
+	}
 	_, newSize := mode.GetPlayerCountLimit()
 	newPlayers := make([]*User, newSize)
 	r.users.Store(&newPlayers)
@@ -210,14 +252,30 @@ func (r *Room) Start() error {
 	r.Lock()
 	defer r.Unlock()
 	mode := *r.mode.Load()
-	users := *r.users.Load()
 	minCount, _ := mode.GetPlayerCountLimit()
-	if currentPlayerCnt := len(users); currentPlayerCnt < minCount {
+	var copiedUsers []lsha.User
+	members := map[int]int{}
+	for _, user := range *r.users.Load() {
+		if user != nil {
+			copiedUsers = append(copiedUsers, user)
+			members[user.Team()]++
+		}
+	}
+	if currentPlayerCnt := len(copiedUsers); currentPlayerCnt < minCount {
 		return fmt.Errorf("not enough users, expected: %d, got: %d", minCount, currentPlayerCnt)
 	}
-	copiedUsers := make([]lsha.User, len(users))
-	for i, user := range users {
-		copiedUsers[i] = user
+	// the users without a team fill the teams which are not full when the game starts,
+	// so it is enough that the users split evenly and no team is over its size.
+	if teams := mode.GetTeamCount(); teams > 0 {
+		if len(copiedUsers)%teams != 0 {
+			return fmt.Errorf("%d users can not be split into %d teams of the same size", len(copiedUsers), teams)
+		}
+		size := len(copiedUsers) / teams
+		for team := 0; team < teams; team++ {
+			if members[team] > size {
+				return fmt.Errorf("team %d has %d users, expected at most: %d", team, members[team], size)
+			}
+		}
 	}
 	go mode.Run(*r.configData.Load(), copiedUsers)
 	return nil
@@ -1,17 +1,27 @@
 package service
 
+import "sync/atomic"
+
 func NewUser(id string) *User {
-	return &User{
+	u := &User{
 		id:       id,
 		roomInfo: &UserRoomInfo{},
 	}
+	u.team.Store(-1)
+	return u
 }
 
 type User struct {
 	id       string
 	roomInfo *UserRoomInfo
+	team     atomic.Int64
 }
 
 func (a *User) ID() string {
 	return a.id
 }
+
+// Team is the team picked in the room, -1 if none was picked.
+func (a *User) Team() int {
+	return int(a.team.Load())
+}
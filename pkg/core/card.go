@@ -0,0 +1,154 @@
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+var _ lsha.Card = (*Card)(nil)
+
+type Card struct {
+	id       uint64
+	name     string
+	cardType lsha.CardType
+	suit     lsha.Suit
+	number   int
+	zone     *Zone
+	marks    marks
+}
+
+func (c *Card) ID() uint64 {
+	return c.id
+}
+
+func (c *Card) Name() string {
+	return c.name
+}
+
+func (c *Card) Type() lsha.CardType {
+	return c.cardType
+}
+
+func (c *Card) Suit() lsha.Suit {
+	return c.suit
+}
+
+func (c *Card) Color() lsha.Color {
+	return c.suit.Color()
+}
+
+func (c *Card) Number() int {
+	return c.number
+}
+
+func (c *runtimeContext) NewCard(name string, cardType lsha.CardType, suit lsha.Suit, number int) lsha.Card {
+	card := &Card{
+		id:       atomic.AddUint64(&c.cardNextID, 1),
+		name:     name,
+		cardType: cardType,
+		suit:     suit,
+		number:   number,
+	}
+	card.marks.holder = card
+	c.drawPile.add(card)
+	return card
+}
+
+func (c *Card) IsVirtual() bool {
+	return false
+}
+
+func (c *Card) Sources() []lsha.Card {
+	return nil
+}
+
+var _ lsha.Card = (*VirtualCard)(nil)
+
+type VirtualCard struct {
+	id       uint64
+	name     string
+	cardType lsha.CardType
+	sources  []lsha.Card
+	marks    marks
+}
+
+func (c *runtimeContext) NewVirtualCard(name string, cardType lsha.CardType, sources ...lsha.Card) lsha.Card {
+	card := &VirtualCard{
+		id:       atomic.AddUint64(&c.cardNextID, 1),
+		name:     name,
+		cardType: cardType,
+		sources:  sources,
+	}
+	card.marks.holder = card
+	return card
+}
+
+func (c *VirtualCard) ID() uint64 {
+	return c.id
+}
+
+func (c *VirtualCard) Name() string {
+	return c.name
+}
+
+func (c *VirtualCard) Type() lsha.CardType {
+	return c.cardType
+}
+
+// Suit is the common suit of all sources, or none if they differ.
+func (c *VirtualCard) Suit() lsha.Suit {
+	if len(c.sources) == 0 {
+		return lsha.SuitNone
+	}
+	suit := c.sources[0].Suit()
+	for _, source := range c.sources[1:] {
+		if source.Suit() != suit {
+			return lsha.SuitNone
+		}
+	}
+	return suit
+}
+
+// Color is the common color of all sources, or none if they differ.
+func (c *VirtualCard) Color() lsha.Color {
+	if len(c.sources) == 0 {
+		return lsha.ColorNone
+	}
+	color := c.sources[0].Color()
+	for _, source := range c.sources[1:] {
+		if source.Color() != color {
+			return lsha.ColorNone
+		}
+	}
+	return color
+}
+
+func (c *VirtualCard) Number() int {
+	if len(c.sources) == 1 {
+		return c.sources[0].Number()
+	}
+	return 0
+}
+
+func (c *VirtualCard) IsVirtual() bool {
+	return true
+}
+
+func (c *VirtualCard) Sources() []lsha.Card {
+	return c.sources
+}
+
+// physicalCards flattens virtual cards into their backing physical cards.
+func physicalCards(cards []lsha.Card) []*Card {
+	result := make([]*Card, 0, len(cards))
+	for _, card := range cards {
+		switch card := card.(type) {
+		case *Card:
+			result = append(result, card)
+		case *VirtualCard:
+			result = append(result, physicalCards(card.sources)...)
+		}
+	}
+	return result
+}
@@ -2,9 +2,11 @@ package core
 
 import (
 	"iter"
+	"math/rand"
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ohanan/LambdaSha/pkg/core/common"
 	"github.com/ohanan/LambdaSha/pkg/lsha"
@@ -23,6 +25,10 @@ func newContext(modeBuilder *modeBuilder, configData any, users []lsha.User) *Co
 			data:           atomic.Pointer[any]{},
 			roomConfigData: configData,
 			accounts:       copiedUsers,
+			drawPile:       newZone(lsha.ZoneDrawPile, nil),
+			discardPile:    newZone(lsha.ZoneDiscardPile, nil),
+			processing:     newZone(lsha.ZoneProcessing, nil),
+			rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
 		},
 		parent: nil,
 	}
@@ -32,17 +38,29 @@ func newContext(modeBuilder *modeBuilder, configData any, users []lsha.User) *Co
 }
 
 type runtimeContext struct {
-	data               atomic.Pointer[any]
-	players            atomic.Pointer[[]*Player]
-	roomConfigData     any
-	runtimeConfig      lsha.ConfigBuilder
-	accounts           []lsha.User
-	turn               atomic.Pointer[Turn]
-	triggers           sync.Map // id -> *Trigger
-	triggerByEventName sync.Map // eventName -> *sync.Map[uint64, *Trigger]
-	triggerNextID      uint64
-	triggerMutex       sync.Mutex
-	modeBuilder        *modeBuilder
+	data                   atomic.Pointer[any]
+	players                atomic.Pointer[[]*Player]
+	roomConfigData         any
+	gameOver               atomic.Bool
+	runtimeConfig          lsha.ConfigBuilder
+	accounts               []lsha.User
+	turn                   atomic.Pointer[Turn]
+	triggers               sync.Map // id -> *Trigger
+	triggerByEventName     sync.Map // eventName -> *sync.Map[uint64, *Trigger]
+	triggerNextID          uint64
+	triggerMutex           sync.Mutex
+	modeBuilder            *modeBuilder
+	drawPile               *Zone
+	discardPile            *Zone
+	processing             *Zone
+	cardNextID             uint64
+	converters             sync.Map // id -> *Converter
+	converterNextID        uint64
+	equipments             sync.Map // card name -> lsha.Equipment
+	delayedTricks          sync.Map // card name -> lsha.DelayedTrick
+	distanceModifiers      sync.Map // id -> *DistanceModifier
+	distanceModifierNextID uint64
+	rand                   *rand.Rand
 }
 
 func (c *runtimeContext) AddTrigger(trigger lsha.Trigger, player lsha.Player, eventNames ...string) (id uint64) {
@@ -73,15 +91,28 @@ func (c *runtimeContext) NextPlayer(player lsha.Player) lsha.Player {
 		return nil
 	}
 	if player == nil {
-		player = c.turn.Load().player
+		if turn := c.turn.Load(); turn != nil {
+			player = turn.player
+		}
 	}
 	if player == nil {
-		player = players[0]
+		// the first turn goes to the first alive player in seat order
+		for _, p := range players {
+			if p.IsAlive() {
+				return p
+			}
+		}
+		return nil
+	}
+	var idx int
+	for i, p := range players {
+		if p == player {
+			idx = i
+			break
+		}
 	}
-	order := player.Order()
-	for i := order + 1; i != order; i = (order + 1) % len(players) {
-		p := players[i]
-		if p.IsAlive() {
+	for i := (idx + 1) % len(players); i != idx; i = (i + 1) % len(players) {
+		if p := players[i]; p.IsAlive() {
 			return p
 		}
 	}
@@ -107,13 +138,33 @@ func (c *runtimeContext) BindData(data any) {
 }
 
 func (c *runtimeContext) Data() any {
-	return c.data.Load()
+	if data := c.data.Load(); data != nil {
+		return *data
+	}
+	return nil
+}
+func (c *runtimeContext) Rand() *rand.Rand {
+	return c.rand
 }
+
 func (c *runtimeContext) RoomConfig() any { return c.roomConfigData }
 func (c *runtimeContext) RuntimeConfig() lsha.ConfigBuilder {
 	return c.runtimeConfig
 }
 
+func (c *runtimeContext) IsGameOver() bool {
+	return c.gameOver.Load()
+}
+
+func (c *Context) EndGame(winners ...lsha.Player) {
+	if !c.gameOver.CompareAndSwap(false, true) {
+		return
+	}
+	event := &lsha.GameOverEvent{}
+	event.SetWinners(winners)
+	c.Invoke(event)
+}
+
 func (c *runtimeContext) Turn() lsha.Turn {
 	return c.turn.Load()
 }
@@ -121,9 +172,17 @@ func (c *runtimeContext) Turn() lsha.Turn {
 func (c *runtimeContext) PlayerIter(start lsha.Player) iter.Seq[lsha.Player] {
 	return func(yield func(lsha.Player) bool) {
 		players := *c.players.Load()
+		if len(players) == 0 {
+			return
+		}
+		if start == nil {
+			if turn := c.turn.Load(); turn != nil {
+				start = turn.player
+			}
+		}
 		var startIdx int
 		for i, player := range players {
-			if player.user.ID() == start.User().ID() {
+			if start != nil && player.user.ID() == start.User().ID() {
 				startIdx = i
 				break
 			}
@@ -133,7 +192,7 @@ func (c *runtimeContext) PlayerIter(start lsha.Player) iter.Seq[lsha.Player] {
 			return
 		}
 		size := len(players)
-		for i := startIdx + 1; i != startIdx; i = (startIdx + 1) % size {
+		for i := (startIdx + 1) % size; i != startIdx; i = (i + 1) % size {
 			player = players[i]
 			if player.IsAlive() && !yield(player) {
 				return
@@ -149,6 +208,11 @@ type Context struct {
 }
 
 func (c *Context) Invoke(event lsha.Event) {
+	// the player is dead when the death is invoked, but the own triggers still react to it
+	var dead lsha.Player
+	if death, ok := event.(*lsha.DeathEvent); ok {
+		dead = death.Player()
+	}
 	var triggers []*Trigger
 	if raw, ok := c.triggerByEventName.Load(event.Name()); ok {
 		raw.(*sync.Map).Range(func(key, value any) bool {
@@ -156,7 +220,7 @@ func (c *Context) Invoke(event lsha.Event) {
 			if _, ok := c.triggers.Load(trigger.id); !ok {
 				return true
 			}
-			if trigger.player == nil || trigger.player.IsAlive() {
+			if trigger.player == nil || trigger.player.IsAlive() || trigger.player == dead {
 				triggers = append(triggers, trigger)
 			}
 			return true
@@ -169,8 +233,8 @@ func (c *Context) Invoke(event lsha.Event) {
 		}
 	}
 	if startOrder < 0 {
-		if p := c.turn.Load().player; p != nil {
-			startOrder = p.order
+		if turn := c.turn.Load(); turn != nil && turn.player != nil {
+			startOrder = turn.player.Order()
 		}
 	}
 	sort.Slice(triggers, func(i, j int) bool {
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type Converter struct {
+	id uint64
+	lsha.Converter
+	player lsha.Player
+}
+
+func (c *runtimeContext) AddConverter(converter lsha.Converter, player lsha.Player) (id uint64) {
+	if converter == nil || converter.Name() == "" || player == nil {
+		return 0
+	}
+	id = atomic.AddUint64(&c.converterNextID, 1)
+	c.converters.Store(id, &Converter{
+		id:        id,
+		Converter: converter,
+		player:    player,
+	})
+	return id
+}
+
+func (c *runtimeContext) RemoveConverter(id uint64) {
+	c.converters.Delete(id)
+}
+
+func (c *runtimeContext) playerConverters(player lsha.Player) map[string]*Converter {
+	result := map[string]*Converter{}
+	c.converters.Range(func(key, value any) bool {
+		converter := value.(*Converter)
+		if converter.player == player {
+			result[converter.Name()] = converter
+		}
+		return true
+	})
+	return result
+}
+
+// cardRequest offers the hand cards and the converted cards of player which match filter.
+func (c *Context) cardRequest(name string, player lsha.Player, filter lsha.CardFilter) *lsha.CardRequest {
+	var candidates []lsha.Card
+	for _, card := range player.Zone(lsha.ZoneHand).Cards() {
+		if filter == nil || filter(card) {
+			candidates = append(candidates, card)
+		}
+	}
+	request := lsha.NewCardRequest(name, candidates, 1, 1)
+	for converterName, converter := range c.playerConverters(player) {
+		if sources := converter.Candidates(c, player); len(sources) > 0 {
+			request.AddConversion(converterName, sources)
+		}
+	}
+	return request
+}
+
+func (c *Context) hasCandidates(request *lsha.CardRequest) bool {
+	return len(request.Cards()) > 0 || len(request.Conversions()) > 0
+}
+
+// replyCard turns a reply of cardRequest into the chosen card, converting it if needed.
+func (c *Context) replyCard(player lsha.Player, reply any, filter lsha.CardFilter) lsha.Card {
+	switch reply := reply.(type) {
+	case []lsha.Card:
+		if len(reply) > 0 {
+			return reply[0]
+		}
+	case *lsha.ConvertReply:
+		converter, ok := c.playerConverters(player)[reply.Converter]
+		if !ok {
+			return nil
+		}
+		card := converter.Convert(c, player, reply.Sources)
+		if card != nil && (filter == nil || filter(card)) {
+			return card
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// testConverter uses a red hand card as Slash.
+type testConverter struct{}
+
+func (t *testConverter) Name() string { return "red_slash" }
+func (t *testConverter) Candidates(ctx lsha.Context, player lsha.Player) []lsha.Card {
+	var cards []lsha.Card
+	for _, card := range player.Zone(lsha.ZoneHand).Cards() {
+		if card.Color() == lsha.ColorRed {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+func (t *testConverter) Convert(ctx lsha.Context, player lsha.Player, sources []lsha.Card) lsha.Card {
+	if len(sources) != 1 || sources[0].Color() != lsha.ColorRed {
+		return nil
+	}
+	return ctx.NewVirtualCard("slash", lsha.CardTypeBasic, sources...)
+}
+
+func TestConversion(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	a, b := players[0], players[1]
+	red := ctx.NewCard("peach", lsha.CardTypeBasic, lsha.SuitHeart, 3)
+	black := ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitSpade, 2)
+	ctx.MoveCards(a.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, red, black)
+	if id := ctx.AddConverter(&testConverter{}, nil); id != 0 {
+		t.Fatal("converter without owner should not be added")
+	}
+	ctx.AddConverter(&testConverter{}, a)
+
+	request := lsha.NewUseCardRequest(ctx.cardRequest(lsha.RequestUseCard, a, lsha.CardNamed("slash")))
+	tests := []struct {
+		name   string
+		reply  *lsha.UseCardReply
+		accept bool
+	}{
+		{name: "red card", reply: &lsha.UseCardReply{Convert: &lsha.ConvertReply{Converter: "red_slash", Sources: []lsha.Card{red}}}, accept: true},
+		{name: "black card", reply: &lsha.UseCardReply{Convert: &lsha.ConvertReply{Converter: "red_slash", Sources: []lsha.Card{black}}}},
+		{name: "unknown converter", reply: &lsha.UseCardReply{Convert: &lsha.ConvertReply{Converter: "other", Sources: []lsha.Card{red}}}},
+		{name: "no sources", reply: &lsha.UseCardReply{Convert: &lsha.ConvertReply{Converter: "red_slash"}}},
+		{name: "unconverted card", reply: &lsha.UseCardReply{Cards: []lsha.Card{red}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if accept := request.Accept(tt.reply); accept != tt.accept {
+				t.Fatalf("accept = %v, want %v", accept, tt.accept)
+			}
+		})
+	}
+
+	reply := &lsha.ConvertReply{Converter: "red_slash", Sources: []lsha.Card{red}}
+	if card := ctx.replyCard(b, reply, nil); card != nil {
+		t.Fatal("converter of a should not be used by b")
+	}
+	card := ctx.replyCard(a, reply, lsha.CardNamed("slash"))
+	if card == nil || !card.IsVirtual() || card.Suit() != lsha.SuitHeart || card.Number() != 3 {
+		t.Fatalf("converted card = %v", card)
+	}
+	ctx.MoveCards(ctx.discardPile, lsha.MoveReasonUse, card)
+	if a.Zone(lsha.ZoneHand).Len() != 1 || ctx.discardPile.Len() != 1 || ctx.discardPile.cards[0] != red {
+		t.Fatal("the source card should be moved with the virtual card")
+	}
+	if mixed := ctx.NewVirtualCard("slash", lsha.CardTypeBasic, red, black); mixed.Suit() != lsha.SuitNone || mixed.Color() != lsha.ColorNone {
+		t.Fatal("virtual card of mixed sources should have no suit and color")
+	}
+}
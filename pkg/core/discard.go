@@ -0,0 +1,55 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// HandLimit equals the hp of player by default and can be changed by HandLimitCalculatingEvent.
+func (c *Context) HandLimit(player lsha.Player) int {
+	if player == nil {
+		return 0
+	}
+	event := &lsha.HandLimitCalculatingEvent{}
+	event.SetPlayer(player)
+	event.SetLimit(max(player.HP(), 0))
+	c.Invoke(event)
+	return max(event.Limit(), 0)
+}
+
+func (c *Context) Discard(player lsha.Player, cards ...lsha.Card) {
+	if player == nil || len(cards) == 0 {
+		return
+	}
+	c.MoveCards(c.discardPile, lsha.MoveReasonDiscard, cards...)
+	event := &lsha.CardsDiscardedEvent{}
+	event.SetPlayer(player)
+	event.SetCards(cards)
+	c.Invoke(event)
+}
+
+// AskDiscard asks player to discard n hand cards matching filter,
+// the first ones are discarded if a forced discard is passed or timed out.
+func (c *Context) AskDiscard(player lsha.Player, n int, filter lsha.CardFilter, forced bool) []lsha.Card {
+	if player == nil || !player.IsAlive() || n <= 0 {
+		return nil
+	}
+	var candidates []lsha.Card
+	for _, card := range player.Zone(lsha.ZoneHand).Cards() {
+		if filter == nil || filter(card) {
+			candidates = append(candidates, card)
+		}
+	}
+	n = min(n, len(candidates))
+	if n == 0 {
+		return nil
+	}
+	cards, _ := c.Ask(player, lsha.NewCardRequest(lsha.RequestDiscard, candidates, n, n)).([]lsha.Card)
+	if len(cards) == 0 {
+		if !forced {
+			return nil
+		}
+		cards = candidates[:n]
+	}
+	c.Discard(player, cards...)
+	return cards
+}
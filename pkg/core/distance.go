@@ -0,0 +1,140 @@
+package core
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type DistanceModifier struct {
+	id uint64
+	lsha.DistanceModifier
+	player lsha.Player
+}
+
+func (c *runtimeContext) AddDistanceModifier(modifier lsha.DistanceModifier, player lsha.Player) (id uint64) {
+	if modifier == nil || modifier.Name() == "" {
+		return 0
+	}
+	id = atomic.AddUint64(&c.distanceModifierNextID, 1)
+	c.distanceModifiers.Store(id, &DistanceModifier{
+		id:               id,
+		DistanceModifier: modifier,
+		player:           player,
+	})
+	return id
+}
+
+func (c *runtimeContext) RemoveDistanceModifier(id uint64) {
+	c.distanceModifiers.Delete(id)
+}
+
+func (c *runtimeContext) sortedDistanceModifiers() []*DistanceModifier {
+	var modifiers []*DistanceModifier
+	c.distanceModifiers.Range(func(key, value any) bool {
+		modifier := value.(*DistanceModifier)
+		if modifier.player == nil || modifier.player.IsAlive() {
+			modifiers = append(modifiers, modifier)
+		}
+		return true
+	})
+	sort.Slice(modifiers, func(i, j int) bool {
+		m1, m2 := modifiers[i], modifiers[j]
+		if p1, p2 := m1.Priority(), m2.Priority(); p1 != p2 {
+			return p1 < p2
+		}
+		return m1.id < m2.id
+	})
+	return modifiers
+}
+
+func (c *runtimeContext) Distance(from, to lsha.Player) int {
+	if from == nil || to == nil || from == to {
+		return 0
+	}
+	distance := c.seatDistance(from, to)
+	for _, modifier := range c.sortedDistanceModifiers() {
+		distance = modifier.ModifyDistance(c, modifier.player, from, to, distance)
+	}
+	return max(distance, 1)
+}
+
+// seatDistance is the smaller number of steps between two players in both directions, skipping dead players.
+func (c *runtimeContext) seatDistance(from, to lsha.Player) int {
+	fromIdx, toIdx, size := -1, -1, 0
+	for _, player := range *c.players.Load() {
+		if player == from {
+			fromIdx = size
+		} else if player == to {
+			toIdx = size
+		} else if !player.IsAlive() {
+			continue
+		}
+		size++
+	}
+	if fromIdx < 0 || toIdx < 0 {
+		return 0
+	}
+	d := toIdx - fromIdx
+	if d < 0 {
+		d = -d
+	}
+	return min(d, size-d)
+}
+
+func (c *runtimeContext) AttackRange(player lsha.Player) int {
+	if player == nil {
+		return 0
+	}
+	attackRange := 1
+	for _, modifier := range c.sortedDistanceModifiers() {
+		attackRange = modifier.ModifyAttackRange(c, modifier.player, player, attackRange)
+	}
+	return max(attackRange, 0)
+}
+
+func (c *runtimeContext) InAttackRange(from, to lsha.Player) bool {
+	if from == nil || to == nil || from == to || !to.IsAlive() {
+		return false
+	}
+	return c.Distance(from, to) <= c.AttackRange(from)
+}
+
+// equipmentModifier applies the default distance rules of an equipment slot.
+type equipmentModifier struct {
+	equipment lsha.Equipment
+}
+
+func (m *equipmentModifier) Name() string {
+	return m.equipment.Name()
+}
+
+// Priority makes weapons set the attack range before other modifiers add to it.
+func (m *equipmentModifier) Priority() float64 {
+	if m.equipment.Slot() == lsha.EquipSlotWeapon {
+		return -1
+	}
+	return 0
+}
+
+func (m *equipmentModifier) ModifyDistance(ctx lsha.RuntimeContext, owner, from, to lsha.Player, distance int) int {
+	switch m.equipment.Slot() {
+	case lsha.EquipSlotOffensiveHorse:
+		if from == owner {
+			return distance - 1
+		}
+	case lsha.EquipSlotDefensiveHorse:
+		if to == owner {
+			return distance + 1
+		}
+	}
+	return distance
+}
+
+func (m *equipmentModifier) ModifyAttackRange(ctx lsha.RuntimeContext, owner, player lsha.Player, attackRange int) int {
+	if m.equipment.Slot() == lsha.EquipSlotWeapon && player == owner {
+		return m.equipment.AttackRange()
+	}
+	return attackRange
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type testEquipment struct {
+	name        string
+	slot        lsha.EquipSlot
+	attackRange int
+	triggers    []lsha.Trigger
+}
+
+func (e *testEquipment) Name() string             { return e.name }
+func (e *testEquipment) Slot() lsha.EquipSlot     { return e.slot }
+func (e *testEquipment) AttackRange() int         { return e.attackRange }
+func (e *testEquipment) Triggers() []lsha.Trigger { return e.triggers }
+
+type testDistanceModifier struct {
+	delta int
+}
+
+func (m *testDistanceModifier) Name() string      { return "test" }
+func (m *testDistanceModifier) Priority() float64 { return 0 }
+func (m *testDistanceModifier) ModifyDistance(ctx lsha.RuntimeContext, owner, from, to lsha.Player, distance int) int {
+	if from == owner {
+		return distance + m.delta
+	}
+	return distance
+}
+func (m *testDistanceModifier) ModifyAttackRange(ctx lsha.RuntimeContext, owner, player lsha.Player, attackRange int) int {
+	if player == owner {
+		return attackRange + 1
+	}
+	return attackRange
+}
+
+func TestDistance(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"}, &testUser{id: "c"}, &testUser{id: "d"}, &testUser{id: "e"})
+	players := *ctx.players.Load()
+	a, c, d := players[0], players[2], players[3]
+	if got := ctx.Distance(a, c); got != 2 {
+		t.Fatalf("seat distance = %d, want 2", got)
+	}
+	if got := ctx.Distance(a, d); got != 2 {
+		t.Fatalf("seat distance backward = %d, want 2", got)
+	}
+
+	ctx.SetEquipment(&testEquipment{name: "horse-", slot: lsha.EquipSlotOffensiveHorse})
+	ctx.SetEquipment(&testEquipment{name: "horse+", slot: lsha.EquipSlotDefensiveHorse})
+	ctx.SetEquipment(&testEquipment{name: "bow", slot: lsha.EquipSlotWeapon, attackRange: 5})
+	ctx.Equip(a, ctx.NewCard("horse-", lsha.CardTypeEquipment, lsha.SuitHeart, 5))
+	ctx.Equip(c, ctx.NewCard("horse+", lsha.CardTypeEquipment, lsha.SuitClub, 5))
+	if got := ctx.Distance(a, c); got != 2 {
+		t.Fatalf("distance with horses = %d, want 2", got)
+	}
+	if got := ctx.Distance(c, a); got != 2 {
+		t.Fatalf("reverse distance with horses = %d, want 2", got)
+	}
+	if ctx.InAttackRange(a, c) {
+		t.Fatal("c should be out of the attack range of a")
+	}
+	bow := ctx.NewCard("bow", lsha.CardTypeEquipment, lsha.SuitHeart, 5)
+	ctx.Equip(a, bow)
+	id := ctx.AddDistanceModifier(&testDistanceModifier{delta: -1}, a)
+	if got := ctx.AttackRange(a); got != 6 {
+		t.Fatalf("attack range = %d, want 6", got)
+	}
+	if got := ctx.Distance(a, c); got != 1 {
+		t.Fatalf("distance with modifier = %d, want 1", got)
+	}
+	ctx.RemoveDistanceModifier(id)
+	ctx.MoveCards(ctx.discardPile, lsha.MoveReasonDeath, bow)
+	if got := ctx.AttackRange(a); got != 1 || a.Equipment(lsha.EquipSlotWeapon) != nil {
+		t.Fatalf("attack range after unequip = %d, want 1", got)
+	}
+}
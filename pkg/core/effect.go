@@ -0,0 +1,137 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type playerEffect struct {
+	effect     lsha.Effect
+	owner      *Player
+	stacks     int
+	remaining  lsha.Duration
+	triggerIDs []uint64
+}
+
+func (e *playerEffect) Effect() lsha.Effect      { return e.effect }
+func (e *playerEffect) Owner() lsha.Player       { return e.owner }
+func (e *playerEffect) Stacks() int              { return e.stacks }
+func (e *playerEffect) Remaining() lsha.Duration { return e.remaining }
+
+// effects keeps the effects of a player in the order they were added.
+type effects []*playerEffect
+
+func (e *effects) get(name string) *playerEffect {
+	for _, effect := range *e {
+		if effect.effect.Name() == name {
+			return effect
+		}
+	}
+	return nil
+}
+
+func (e *effects) Get(name string) lsha.PlayerEffect {
+	if effect := e.get(name); effect != nil {
+		return effect
+	}
+	return nil
+}
+
+func (e *effects) Has(name string) bool {
+	return e.get(name) != nil
+}
+
+func (e *effects) List() []lsha.PlayerEffect {
+	list := make([]lsha.PlayerEffect, len(*e))
+	for i, effect := range *e {
+		list[i] = effect
+	}
+	return list
+}
+
+func (e *effects) Visible(viewer lsha.Player) []lsha.PlayerEffect {
+	var list []lsha.PlayerEffect
+	for _, effect := range *e {
+		if effect.effect.Visibility().VisibleTo(effect.owner, viewer) {
+			list = append(list, effect)
+		}
+	}
+	return list
+}
+
+func (c *Context) AddEffect(player lsha.Player, effect lsha.Effect, stacks int, duration lsha.Duration) lsha.PlayerEffect {
+	p, ok := player.(*Player)
+	if !ok || effect == nil || stacks <= 0 || !p.IsAlive() {
+		return nil
+	}
+	pe := p.effects.get(effect.Name())
+	if pe == nil {
+		pe = &playerEffect{effect: effect, owner: p}
+		for _, trigger := range effect.Triggers() {
+			if id := c.AddTrigger(trigger, p, trigger.EventName()); id > 0 {
+				pe.triggerIDs = append(pe.triggerIDs, id)
+			}
+		}
+		p.effects = append(p.effects, pe)
+	}
+	pe.stacks += stacks
+	pe.remaining = duration
+	event := &lsha.EffectAddedEvent{}
+	event.SetPlayer(p)
+	event.SetEffect(pe)
+	event.SetStacks(stacks)
+	c.Invoke(event)
+	return pe
+}
+
+func (c *Context) RemoveEffect(player lsha.Player, name string, stacks int) bool {
+	p, ok := player.(*Player)
+	if !ok {
+		return false
+	}
+	return c.removeEffect(p, name, stacks, false)
+}
+
+func (c *Context) removeEffect(p *Player, name string, stacks int, expired bool) bool {
+	for i, pe := range p.effects {
+		if pe.effect.Name() != name {
+			continue
+		}
+		if stacks <= 0 || stacks >= pe.stacks {
+			stacks = pe.stacks
+			for _, id := range pe.triggerIDs {
+				c.RemoveTrigger(id)
+			}
+			p.effects = append(p.effects[:i], p.effects[i+1:]...)
+		}
+		pe.stacks -= stacks
+		event := &lsha.EffectRemovedEvent{}
+		event.SetPlayer(p)
+		event.SetEffect(pe)
+		event.SetStacks(stacks)
+		event.SetExpired(expired)
+		c.Invoke(event)
+		return true
+	}
+	return false
+}
+
+// tickEffects counts down the effects lasting for unit, effects of owner only if owner is not nil.
+func (c *Context) tickEffects(unit lsha.DurationUnit, owner *Player) {
+	for _, p := range *c.players.Load() {
+		if owner != nil && p != owner {
+			continue
+		}
+		var expired []string
+		for _, pe := range p.effects {
+			if pe.remaining.Unit != unit {
+				continue
+			}
+			if pe.remaining.Count--; pe.remaining.Count <= 0 {
+				expired = append(expired, pe.effect.Name())
+			}
+		}
+		for _, name := range expired {
+			c.removeEffect(p, name, 0, true)
+		}
+	}
+}
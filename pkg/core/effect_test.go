@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestEffects(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	var invoked int
+	effect := lsha.NewEffect("seal").Visible(lsha.VisibilityOwner).Trigger(&testTrigger{
+		name:      "seal",
+		eventName: lsha.EventChainChanged,
+		invoke:    func(ctx lsha.Context) { invoked++ },
+	})
+	ctx.AddEffect(players[0], effect, 1, lsha.Turns(2))
+	ctx.AddEffect(players[0], effect, 2, lsha.Turns(2))
+	if pe := players[0].Effects().Get("seal"); pe == nil || pe.Stacks() != 3 {
+		t.Fatalf("stacks = %v, want 3", pe)
+	}
+	if len(players[0].Effects().Visible(players[1])) != 0 || len(players[0].Effects().Visible(players[0])) != 1 {
+		t.Fatal("effect should only be visible to its owner")
+	}
+	ctx.ToggleChained(players[0])
+	if invoked != 1 {
+		t.Fatalf("invoked = %d, want 1", invoked)
+	}
+	ctx.RemoveEffect(players[0], "seal", 1)
+	ctx.tickEffects(lsha.DurationTurns, players[0])
+	if pe := players[0].Effects().Get("seal"); pe == nil || pe.Stacks() != 2 {
+		t.Fatalf("stacks = %v, want 2", pe)
+	}
+	ctx.tickEffects(lsha.DurationTurns, players[0])
+	if players[0].Effects().Has("seal") {
+		t.Fatal("effect should expire after 2 turns")
+	}
+	ctx.ToggleChained(players[0])
+	if invoked != 1 {
+		t.Fatalf("invoked = %d, trigger should be removed with the effect", invoked)
+	}
+	if ctx.AddEffect(nil, effect, 1, lsha.Permanent()) != nil || ctx.RemoveEffect(nil, "seal", 0) {
+		t.Fatal("effects should not be changed without a player")
+	}
+}
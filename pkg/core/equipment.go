@@ -0,0 +1,81 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type equippedCard struct {
+	card       *Card
+	triggerIDs []uint64
+	modifierID uint64
+}
+
+func (c *runtimeContext) SetEquipment(equipment lsha.Equipment) {
+	if equipment == nil || equipment.Name() == "" {
+		return
+	}
+	c.equipments.Store(equipment.Name(), equipment)
+}
+
+func (c *runtimeContext) Equipment(card lsha.Card) lsha.Equipment {
+	if card == nil || card.Type() != lsha.CardTypeEquipment {
+		return nil
+	}
+	if v, ok := c.equipments.Load(card.Name()); ok {
+		return v.(lsha.Equipment)
+	}
+	return nil
+}
+
+func (c *Context) Equip(player lsha.Player, card lsha.Card) (equipped bool) {
+	p, ok := player.(*Player)
+	if !ok || !p.IsAlive() {
+		return false
+	}
+	physical, ok := card.(*Card)
+	if !ok {
+		return false
+	}
+	equipment := c.Equipment(card)
+	if equipment == nil {
+		return false
+	}
+	slot := equipment.Slot()
+	if old := p.equips[slot]; old != nil {
+		if old.card == physical {
+			return true
+		}
+		c.MoveCards(c.discardPile, lsha.MoveReasonReplace, old.card)
+	}
+	c.MoveCards(p.zones[lsha.ZoneEquip], lsha.MoveReasonEquip, physical)
+	if physical.zone != p.zones[lsha.ZoneEquip] {
+		return false
+	}
+	e := &equippedCard{card: physical}
+	for _, trigger := range equipment.Triggers() {
+		if id := c.AddTrigger(trigger, p, trigger.EventName()); id > 0 {
+			e.triggerIDs = append(e.triggerIDs, id)
+		}
+	}
+	if modifier, ok := equipment.(lsha.DistanceModifier); ok {
+		e.modifierID = c.AddDistanceModifier(modifier, p)
+	} else {
+		e.modifierID = c.AddDistanceModifier(&equipmentModifier{equipment: equipment}, p)
+	}
+	p.equips[slot] = e
+	return true
+}
+
+// unequip clears the slot holding card and removes the triggers and modifier of its equipment.
+func (c *runtimeContext) unequip(p *Player, card *Card) {
+	for slot, e := range p.equips {
+		if e.card == card {
+			for _, id := range e.triggerIDs {
+				c.RemoveTrigger(id)
+			}
+			c.RemoveDistanceModifier(e.modifierID)
+			delete(p.equips, slot)
+			return
+		}
+	}
+}
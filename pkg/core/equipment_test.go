@@ -0,0 +1,42 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestEquipmentTriggers(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"})
+	a := (*ctx.players.Load())[0]
+	var fired []string
+	for _, name := range []string{"sword", "axe"} {
+		ctx.SetEquipment(&testEquipment{name: name, slot: lsha.EquipSlotWeapon, triggers: []lsha.Trigger{
+			&testTrigger{name: name, eventName: lsha.EventGameStarted, invoke: func(ctx lsha.Context) {
+				fired = append(fired, name)
+			}},
+		}})
+	}
+	fire := func() []string {
+		fired = nil
+		ctx.Invoke(&lsha.GameStartedEvent{})
+		return fired
+	}
+	sword := ctx.NewCard("sword", lsha.CardTypeEquipment, lsha.SuitSpade, 1)
+	axe := ctx.NewCard("axe", lsha.CardTypeEquipment, lsha.SuitSpade, 2)
+	if got := fire(); len(got) != 0 {
+		t.Fatalf("fired %v before equipping", got)
+	}
+	ctx.Equip(a, sword)
+	if got := fire(); len(got) != 1 || got[0] != "sword" {
+		t.Fatalf("fired %v, want [sword] while equipped", got)
+	}
+	ctx.Equip(a, axe)
+	if got := fire(); len(got) != 1 || got[0] != "axe" || cardZone(sword) != ctx.discardPile {
+		t.Fatalf("fired %v, want [axe] after replacing the sword", got)
+	}
+	ctx.MoveCards(ctx.discardPile, lsha.MoveReasonDiscard, axe)
+	if got := fire(); len(got) != 0 || a.Equipment(lsha.EquipSlotWeapon) != nil {
+		t.Fatalf("fired %v after discarding the axe", got)
+	}
+}
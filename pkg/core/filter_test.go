@@ -0,0 +1,57 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestPlayerFilters(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"}, &testUser{id: "c"}, &testUser{id: "d"}, &testUser{id: "e"})
+	players := *ctx.players.Load()
+	a, b, c, d := players[0], players[1], players[2], players[3]
+	d.dead = true
+	ctx.MoveCards(b.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, ctx.NewCard("slash", lsha.CardTypeBasic, lsha.SuitSpade, 1))
+	ctx.MoveCards(c.Zone(lsha.ZoneJudge), lsha.MoveReasonDelay, ctx.NewCard("lightning", lsha.CardTypeDelayedTrick, lsha.SuitSpade, 1))
+	hasHand := lsha.HasCardsIn(lsha.ZoneHand)
+	tests := []struct {
+		name   string
+		start  lsha.Player
+		filter lsha.PlayerFilter
+		want   string
+	}{
+		{name: "nil filter", start: a, want: "[a b c e]"},
+		{name: "from another player", start: c, want: "[c e a b]"},
+		{name: "match all", start: a, filter: lsha.MatchAll(nil, lsha.Other(b), lsha.WithinDistance(ctx, a, 2)), want: "[c e]"},
+		{name: "match all of nothing", start: a, filter: lsha.MatchAll(), want: "[a b c e]"},
+		{name: "match any", start: a, filter: lsha.MatchAny(hasHand, lsha.Other(a)), want: "[b c e]"},
+		{name: "match any of nothing", start: a, filter: lsha.MatchAny(), want: "[]"},
+		{name: "match any nil", start: a, filter: lsha.MatchAny(hasHand, nil), want: "[a b c e]"},
+		{name: "not", start: a, filter: lsha.Not(hasHand), want: "[a c e]"},
+		{name: "not nil", start: a, filter: lsha.Not(nil), want: "[]"},
+		{name: "within distance", start: a, filter: lsha.WithinDistance(ctx, a, 1), want: "[b e]"},
+		{name: "has cards in zones", start: a, filter: lsha.HasCardsIn(lsha.ZoneEquip, lsha.ZoneJudge, lsha.ZoneHand), want: "[b c]"},
+		{name: "has cards in no zone", start: a, filter: lsha.HasCardsIn(), want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, p := range lsha.Players(ctx, tt.start, tt.filter) {
+				ids = append(ids, p.User().ID())
+			}
+			if got := fmt.Sprint(ids); got != tt.want {
+				t.Fatalf("players = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	var visited []string
+	lsha.FilterPlayers(ctx, a, lsha.Other(a))(func(p lsha.Player) bool {
+		visited = append(visited, p.User().ID())
+		return len(visited) < 2
+	})
+	if want := "[b c]"; fmt.Sprint(visited) != want {
+		t.Fatalf("visited = %v, want %s, the iteration should stop when yield returns false", visited, want)
+	}
+}
@@ -243,16 +243,13 @@ func (r *RadioBuilder) makeItem(readonly bool) *Item {
 	if len(radios) == 0 {
 		return nil
 	}
-	onChecked := r.onChecked
 	return &Item{
-		ID:     r.b.nextIDStr(readonly),
-		Type:   ItemTypeRadio,
-		Label:  r.name,
-		Tips:   r.tips,
-		Radios: radios,
-		onRadiosChecked: func(label, radioLabel string) {
-			onChecked(label, radioLabel)
-		},
+		ID:              r.b.nextIDStr(readonly),
+		Type:            ItemTypeRadio,
+		Label:           r.name,
+		Tips:            r.tips,
+		Radios:          radios,
+		onRadiosChecked: r.onChecked,
 	}
 }
 
@@ -414,6 +411,7 @@ func (b *ItemsBuilder) Checkbox(name string, tips string) lsha.ConfigCheckboxOpt
 		tips: tips,
 		b:    b,
 	}
+	b.itemMakers = append(b.itemMakers, bb)
 	return bb
 }
 
@@ -423,11 +421,12 @@ func (b *ItemsBuilder) Radio(name string, tips string) lsha.ConfigRadioOptionsBu
 		tips: tips,
 		b:    b,
 	}
+	b.itemMakers = append(b.itemMakers, bb)
 	return bb
 }
 
 func (b *ItemsBuilder) Range(name string, tips string) lsha.ConfigRangeOptionsBuilder {
-	return &RangeBuilder{
+	bb := &RangeBuilder{
 		b: b,
 		r: &Range{
 			ValueLabel: map[int64]string{},
@@ -435,6 +434,8 @@ func (b *ItemsBuilder) Range(name string, tips string) lsha.ConfigRangeOptionsBu
 		name: name,
 		tips: tips,
 	}
+	b.itemMakers = append(b.itemMakers, bb)
+	return bb
 }
 
 func (b *ItemsBuilder) nextIDStr(readonly bool) string {
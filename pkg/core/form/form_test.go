@@ -43,3 +43,34 @@ func TestConvertItems(t *testing.T) {
 	})
 	p(td)
 }
+
+func TestItemsBuilderRadio(t *testing.T) {
+	var checked string
+	b := &ItemsBuilder{}
+	b.Radio("Radios", "").AddOption("a", "").AddOption("b", "").CheckOption("a").
+		OnCheckedOption(func(data any, name, radioName string) { checked = radioName })
+	items := b.Build(false)
+	if len(items) != 1 || len(items[0].Radios) != 2 || !items[0].Radios[0].Checked {
+		t.Fatalf("unexpected items %+v", items)
+	}
+	UpdateItems(items, map[string]any{items[0].ID + "." + items[0].Radios[1].ID: true})
+	if checked != "b" {
+		t.Fatalf("checked = %q, want b", checked)
+	}
+}
+
+func TestItemsBuilderMakers(t *testing.T) {
+	b := &ItemsBuilder{}
+	b.Desc("desc")
+	b.Checkbox("Checkboxes", "").AddOption("a", "", true, nil)
+	b.Radio("Radios", "").AddOption("a", "")
+	b.Range("Range", "").Min(1, "").Max(3, "").Value(2)
+	items := b.Build(false)
+	var types []ItemType
+	for _, item := range items {
+		types = append(types, item.Type)
+	}
+	if len(types) != 4 || types[0] != ItemTypeDesc || types[1] != ItemTypeCheckbox || types[2] != ItemTypeRadio || types[3] != ItemTypeRange {
+		t.Fatalf("item types = %v, every added item should be built in order", types)
+	}
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func (p *Player) HP() int {
+	return p.hp
+}
+
+func (p *Player) MaxHP() int {
+	return p.maxHP
+}
+
+func (c *runtimeContext) SetMaxHP(player lsha.Player, maxHP int) {
+	if p, ok := player.(*Player); ok {
+		p.maxHP = max(maxHP, 0)
+		p.hp = min(p.hp, p.maxHP)
+	}
+}
+
+func (c *runtimeContext) SetHP(player lsha.Player, hp int) {
+	if p, ok := player.(*Player); ok {
+		p.hp = min(hp, p.maxHP)
+	}
+}
+
+func (c *Context) Damage(damage *lsha.Damage) (dealt int) {
+	if damage == nil || damage.Target() == nil || !damage.Target().IsAlive() {
+		return 0
+	}
+	c.Invoke(&lsha.DamageCausingEvent{Damage: damage})
+	if damage.Prevented() {
+		return 0
+	}
+	c.Invoke(&lsha.DamageInflictingEvent{Damage: damage})
+	if damage.Prevented() {
+		return 0
+	}
+	target, ok := damage.Target().(*Player)
+	if !ok || !target.IsAlive() {
+		return 0
+	}
+	dealt = damage.Amount()
+	target.hp -= dealt
+	chainTriggered := damage.IsElemental() && target.chained
+	if chainTriggered {
+		c.SetChained(target, false)
+	}
+	c.Invoke(&lsha.DamageDoneEvent{Damage: damage})
+	if target.IsAlive() && target.hp <= 0 {
+		c.dying(target, damage)
+	}
+	if chainTriggered && !damage.IsChained() {
+		c.propagateChain(damage)
+	}
+	return dealt
+}
+
+// propagateChain deals the same damage to the other chained players in seat order.
+func (c *Context) propagateChain(damage *lsha.Damage) {
+	var chained []lsha.Player
+	c.PlayerIter(nil)(func(player lsha.Player) bool {
+		if player != damage.Target() && player.IsChained() {
+			chained = append(chained, player)
+		}
+		return true
+	})
+	for _, player := range chained {
+		if !player.IsChained() {
+			continue
+		}
+		d := lsha.NewDamage(damage.Source(), player, damage.Amount(), damage.Nature(), damage.Card())
+		d.SetChainedFrom(damage)
+		c.Damage(d)
+	}
+}
+
+func (p *Player) IsChained() bool {
+	return p.chained
+}
+
+func (c *Context) SetChained(player lsha.Player, chained bool) {
+	p, ok := player.(*Player)
+	if !ok || !p.IsAlive() || p.chained == chained {
+		return
+	}
+	p.chained = chained
+	event := &lsha.ChainChangedEvent{}
+	event.SetPlayer(p)
+	event.SetChained(chained)
+	c.Invoke(event)
+}
+
+func (c *Context) ToggleChained(player lsha.Player) {
+	c.SetChained(player, !player.IsChained())
+}
+
+func (c *Context) Heal(heal *lsha.Heal) (healed int) {
+	if heal == nil || heal.Target() == nil || !heal.Target().IsAlive() {
+		return 0
+	}
+	c.Invoke(&lsha.HealingEvent{Heal: heal})
+	target, ok := heal.Target().(*Player)
+	if heal.Prevented() || !ok || !target.IsAlive() {
+		return 0
+	}
+	healed = min(heal.Amount(), target.maxHP-target.hp)
+	if healed <= 0 {
+		return 0
+	}
+	heal.SetAmount(healed)
+	target.hp += healed
+	c.Invoke(&lsha.HealedEvent{Heal: heal})
+	return healed
+}
+
+// dying gives everyone the chance to rescue the player, who dies if the hp is still not positive.
+func (c *Context) dying(player *Player, damage *lsha.Damage) {
+	event := &lsha.DyingEvent{}
+	event.SetPlayer(player)
+	event.SetDamage(damage)
+	c.Invoke(event)
+	if player.IsAlive() && player.hp <= 0 {
+		c.Kill(player, damage)
+	}
+}
+
+func (c *Context) Kill(player lsha.Player, damage *lsha.Damage) {
+	p, ok := player.(*Player)
+	if !ok || !p.IsAlive() {
+		return
+	}
+	p.dead = true
+	event := &lsha.DeathEvent{}
+	event.SetPlayer(p)
+	event.SetDamage(damage)
+	c.Invoke(event)
+	for _, zoneType := range []lsha.ZoneType{lsha.ZoneHand, lsha.ZoneEquip, lsha.ZoneJudge} {
+		c.MoveCards(c.discardPile, lsha.MoveReasonDeath, p.zones[zoneType].Cards()...)
+	}
+	for _, pile := range p.piles {
+		c.MoveCards(c.discardPile, lsha.MoveReasonDeath, pile.Cards()...)
+	}
+	for len(p.effects) > 0 {
+		c.removeEffect(p, p.effects[0].effect.Name(), 0, false)
+	}
+}
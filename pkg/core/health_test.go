@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestDamageChain(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"}, &testUser{id: "c"}, &testUser{id: "d"})
+	players := *ctx.players.Load()
+	for _, player := range players {
+		ctx.SetMaxHP(player, 4)
+		ctx.SetHP(player, 4)
+	}
+	ctx.SetChained(players[1], true)
+	ctx.SetChained(players[2], true)
+	ctx.SetChained(players[3], true)
+	var damaged []string
+	ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+		damaged = append(damaged, ctx.Event().(*lsha.DamageDoneEvent).Target().User().ID())
+	}}, nil, lsha.EventDamageDone)
+
+	ctx.Damage(lsha.NewDamage(players[0], players[2], 1, lsha.DamageFire, nil))
+	if want := []string{"c", "b", "d"}; len(damaged) != len(want) || damaged[0] != want[0] || damaged[1] != want[1] || damaged[2] != want[2] {
+		t.Fatalf("damaged = %v, want %v", damaged, want)
+	}
+	for _, player := range players {
+		if player.IsChained() {
+			t.Fatalf("player %s is still chained", player.User().ID())
+		}
+	}
+
+	damaged = nil
+	ctx.SetChained(players[1], true)
+	ctx.SetChained(players[3], true)
+	ctx.Damage(lsha.NewDamage(players[0], players[3], 1, lsha.DamageNormal, nil))
+	if len(damaged) != 1 || !players[1].IsChained() || !players[3].IsChained() {
+		t.Fatalf("normal damage must not propagate, damaged = %v", damaged)
+	}
+}
+
+func TestDamageKill(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	ctx.SetMaxHP(players[1], 3)
+	ctx.SetHP(players[1], 1)
+	var killer lsha.Player
+	ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+		killer = ctx.Event().(*lsha.DeathEvent).Killer()
+	}}, nil, lsha.EventDeath)
+	ctx.Damage(lsha.NewDamage(players[0], players[1], 2, lsha.DamageNormal, nil))
+	if players[1].IsAlive() || killer != players[0] {
+		t.Fatalf("player b should be killed by a, alive: %v, killer: %v", players[1].IsAlive(), killer)
+	}
+}
+
+func TestDying(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	a, b := players[0], players[1]
+	for _, player := range players {
+		ctx.SetMaxHP(player, 2)
+		ctx.SetHP(player, 2)
+	}
+	var rescued, deaths int
+	ctx.AddTrigger(&testTrigger{name: "rescue", invoke: func(ctx lsha.Context) {
+		if dying := ctx.Event().(*lsha.DyingEvent).Player(); rescued == 0 {
+			rescued++
+			ctx.Heal(lsha.NewHeal(a, dying, 1, nil))
+		}
+	}}, a, lsha.EventDying)
+	ctx.AddTrigger(&testTrigger{name: "last_words", invoke: func(ctx lsha.Context) {
+		if ctx.Event().(*lsha.DeathEvent).Player() == b {
+			deaths++
+		}
+	}}, b, lsha.EventDeath)
+	ctx.AddTrigger(&testTrigger{name: "chain", invoke: func(ctx lsha.Context) {
+		t.Fatal("triggers of a dead player should not react to other events")
+	}}, b, lsha.EventChainChanged)
+
+	ctx.Damage(lsha.NewDamage(a, b, 2, lsha.DamageNormal, nil))
+	if !b.IsAlive() || b.HP() != 1 || rescued != 1 {
+		t.Fatalf("b should be rescued to 1 hp, alive: %v, hp: %d", b.IsAlive(), b.HP())
+	}
+	ctx.Damage(lsha.NewDamage(a, b, 1, lsha.DamageNormal, nil))
+	if b.IsAlive() || deaths != 1 {
+		t.Fatalf("b should die and its own death trigger should fire, alive: %v, deaths: %d", b.IsAlive(), deaths)
+	}
+	ctx.ToggleChained(a)
+}
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+var _ lsha.Hero = (*Hero)(nil)
+
+type Hero struct {
+	def      lsha.HeroDef
+	player   *Player
+	slot     lsha.HeroSlot
+	revealed bool
+}
+
+func (h *Hero) Def() lsha.HeroDef {
+	return h.def
+}
+
+func (h *Hero) Player() lsha.Player {
+	return h.player
+}
+
+func (h *Hero) Slot() lsha.HeroSlot {
+	return h.slot
+}
+
+func (h *Hero) IsRevealed() bool {
+	return h.revealed
+}
+
+func (c *runtimeContext) HeroDefs() []lsha.HeroDef {
+	return c.modeBuilder.HeroDefs()
+}
+
+func (c *runtimeContext) HeroDef(id string) lsha.HeroDef {
+	return c.modeBuilder.heroDefs[id]
+}
+
+// AssignHero gives def to player, resets the max hp and hp of player by it
+// and replaces the skills of the previous heroes with the skills of def.
+func (c *Context) AssignHero(player lsha.Player, def lsha.HeroDef) lsha.Hero {
+	p, ok := player.(*Player)
+	if !ok || def == nil {
+		return nil
+	}
+	c.clearHeroes(p)
+	hero := c.placeHero(p, lsha.HeroPrimary, def, true)
+	c.SetMaxHP(p, def.MaxHP())
+	c.SetHP(p, def.MaxHP())
+	return hero
+}
+
+func (c *Context) AssignDualHeroes(player lsha.Player, primary, secondary lsha.HeroDef, revealed bool) (lsha.Hero, lsha.Hero) {
+	p, ok := player.(*Player)
+	if !ok || primary == nil || secondary == nil {
+		return nil, nil
+	}
+	c.clearHeroes(p)
+	first := c.placeHero(p, lsha.HeroPrimary, primary, revealed)
+	second := c.placeHero(p, lsha.HeroSecondary, secondary, revealed)
+	maxHP := c.modeBuilder.combinedMaxHP(primary, secondary)
+	c.SetMaxHP(p, maxHP)
+	c.SetHP(p, maxHP)
+	return first, second
+}
+
+func (c *Context) RevealHero(player lsha.Player, slot lsha.HeroSlot) bool {
+	p, ok := player.(*Player)
+	if !ok || p.heroAt(slot) == nil || p.heroes[slot].revealed {
+		return false
+	}
+	hero := p.heroes[slot]
+	hero.revealed = true
+	c.gainHeroSkills(p, hero.def)
+	event := &lsha.HeroRevealedEvent{}
+	event.SetPlayer(p)
+	event.SetHero(hero)
+	c.Invoke(event)
+	return true
+}
+
+func (c *Context) ChangeHero(player lsha.Player, slot lsha.HeroSlot, def lsha.HeroDef) lsha.Hero {
+	p, ok := player.(*Player)
+	if !ok || def == nil || p.heroAt(slot) == nil {
+		return nil
+	}
+	old := p.heroes[slot]
+	if old.revealed {
+		c.loseHeroSkills(p, old)
+	}
+	hero := c.placeHero(p, slot, def, old.revealed)
+	event := &lsha.HeroChangedEvent{}
+	event.SetPlayer(p)
+	event.SetOld(old.def)
+	event.SetHero(hero)
+	c.Invoke(event)
+	return hero
+}
+
+func (c *Context) clearHeroes(p *Player) {
+	for slot, hero := range p.heroes {
+		if hero != nil && hero.revealed {
+			c.loseHeroSkills(p, hero)
+		}
+		p.heroes[slot] = nil
+	}
+}
+
+func (c *Context) placeHero(p *Player, slot lsha.HeroSlot, def lsha.HeroDef, revealed bool) *Hero {
+	hero := &Hero{
+		def:      def,
+		player:   p,
+		slot:     slot,
+		revealed: revealed,
+	}
+	p.heroes[slot] = hero
+	if revealed {
+		c.gainHeroSkills(p, def)
+	}
+	return hero
+}
+
+func (c *Context) gainHeroSkills(p *Player, def lsha.HeroDef) {
+	for _, name := range def.Skills() {
+		if p.lord || !c.lordOnly(name) {
+			c.gainSkill(p, name, true)
+		}
+	}
+}
+
+// loseHeroSkills loses the skills gained from hero, unless another revealed hero of p provides them.
+func (c *Context) loseHeroSkills(p *Player, hero *Hero) {
+	for _, name := range hero.def.Skills() {
+		if skill := p.skill(name); skill != nil && skill.hero && !c.heroProvides(p, hero, name) {
+			c.LoseSkill(p, name)
+		}
+	}
+}
+
+// heroProvides tells whether a revealed hero of p other than except provides the skill.
+func (c *Context) heroProvides(p *Player, except *Hero, name string) bool {
+	if !p.lord && c.lordOnly(name) {
+		return false
+	}
+	for _, hero := range p.heroes {
+		if hero == nil || hero == except || !hero.revealed {
+			continue
+		}
+		for _, skill := range hero.def.Skills() {
+			if skill == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Kingdom falls back to the kingdom of the first revealed hero.
+func (p *Player) Kingdom() lsha.Kingdom {
+	if p.kingdom != "" {
+		return p.kingdom
+	}
+	for _, hero := range p.heroes {
+		if hero != nil && hero.revealed {
+			return hero.def.Kingdom()
+		}
+	}
+	return ""
+}
+
+func (p *Player) Role() lsha.Role {
+	return p.role
+}
+
+func (p *Player) RoleVisibleTo(viewer lsha.Player) bool {
+	return p.roleVisibility.VisibleTo(p, viewer)
+}
+
+func (c *runtimeContext) SetRoleVisibility(player lsha.Player, visibility lsha.Visibility) {
+	if p, ok := player.(*Player); ok {
+		p.roleVisibility = visibility
+	}
+}
+
+func (p *Player) IsLord() bool {
+	return p.lord
+}
+
+func (p *Player) Team() int {
+	return p.team
+}
+
+func (c *runtimeContext) SetKingdom(player lsha.Player, kingdom lsha.Kingdom) {
+	if p, ok := player.(*Player); ok {
+		p.kingdom = kingdom
+	}
+}
+
+func (c *runtimeContext) lordOnly(skill string) bool {
+	def := c.SkillDef(skill)
+	return def != nil && def.Tags().Has(lsha.SkillTagLordOnly)
+}
+
+func (c *Context) SetLord(player lsha.Player, lord bool) {
+	p, ok := player.(*Player)
+	if !ok || p.lord == lord {
+		return
+	}
+	p.lord = lord
+	for _, hero := range p.heroes {
+		if hero == nil || !hero.revealed {
+			continue
+		}
+		for _, name := range hero.def.Skills() {
+			if !c.lordOnly(name) {
+				continue
+			}
+			if lord {
+				c.gainSkill(p, name, true)
+			} else if skill := p.skill(name); skill != nil && skill.hero {
+				c.LoseSkill(p, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestLordAndKingdom(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"}, &testUser{id: "c"}, &testUser{id: "d"})
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("rouse").Tag(lsha.SkillTagLordOnly))
+	lord := lsha.NewHeroDef("liubei", "刘备", lsha.KingdomShu, lsha.GenderMale, 4, "rouse")
+	shu := lsha.NewHeroDef("guanyu", "关羽", lsha.KingdomShu, lsha.GenderMale, 4)
+	wei := lsha.NewHeroDef("caocao", "曹操", lsha.KingdomWei, lsha.GenderMale, 4)
+	players := *ctx.players.Load()
+	ctx.AssignHero(players[0], lord)
+	ctx.AssignHero(players[1], shu)
+	ctx.AssignHero(players[2], wei)
+	ctx.AssignHero(players[3], shu)
+	if players[0].HasSkill("rouse") {
+		t.Fatal("lord-only skill should not be gained by a non-lord player")
+	}
+	ctx.SetLord(players[0], true)
+	if !players[0].HasSkill("rouse") {
+		t.Fatal("lord-only skill should be gained by the lord")
+	}
+	ctx.SetKingdom(players[2], lsha.KingdomShu)
+	ctx.Kill(players[3], nil)
+	var ids []string
+	lsha.KingdomPlayers(ctx, players[0], lsha.KingdomShu)(func(p lsha.Player) bool {
+		ids = append(ids, p.User().ID())
+		return true
+	})
+	if len(ids) != 2 || ids[0] != "b" || ids[1] != "c" {
+		t.Fatalf("shu players = %v, want [b c]", ids)
+	}
+}
+
+func TestDualHeroes(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("jianxiong"))
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("fankui"))
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("ganglie"))
+	caocao := lsha.NewHeroDef("caocao", "曹操", lsha.KingdomWei, lsha.GenderMale, 4, "jianxiong")
+	simayi := lsha.NewHeroDef("simayi", "司马懿", lsha.KingdomWei, lsha.GenderMale, 3, "fankui")
+	xiahoudun := lsha.NewHeroDef("xiahoudun", "夏侯惇", lsha.KingdomWei, lsha.GenderMale, 4, "ganglie")
+	player := (*ctx.players.Load())[0]
+	ctx.AssignDualHeroes(player, caocao, simayi, false)
+	if player.MaxHP() != 3 || len(player.Skills()) != 0 || player.Kingdom() != "" {
+		t.Fatalf("max hp %d, skills %d, kingdom %q", player.MaxHP(), len(player.Skills()), player.Kingdom())
+	}
+	if !ctx.RevealHero(player, lsha.HeroSecondary) || ctx.RevealHero(player, lsha.HeroSecondary) {
+		t.Fatal("hero should be revealed once")
+	}
+	if !player.HasSkill("fankui") || player.HasSkill("jianxiong") || player.Kingdom() != lsha.KingdomWei {
+		t.Fatal("only the skills of the revealed hero should be gained")
+	}
+	ctx.ChangeHero(player, lsha.HeroSecondary, xiahoudun)
+	if player.HasSkill("fankui") || !player.HasSkill("ganglie") || player.SecondaryHero().Def() != xiahoudun {
+		t.Fatal("changed hero should replace the skills")
+	}
+
+	ctx.RevealHero(player, lsha.HeroPrimary)
+	ctx.ChangeHero(player, lsha.HeroSecondary, lsha.NewHeroDef("caopi", "曹丕", lsha.KingdomWei, lsha.GenderMale, 3, "jianxiong"))
+	ctx.ChangeHero(player, lsha.HeroPrimary, simayi)
+	if !player.HasSkill("jianxiong") {
+		t.Fatal("skill provided by the other revealed hero should be kept")
+	}
+	ctx.GainSkill(player, "fankui")
+	ctx.ChangeHero(player, lsha.HeroSecondary, xiahoudun)
+	ctx.ChangeHero(player, lsha.HeroPrimary, caocao)
+	if !player.HasSkill("fankui") || !player.HasSkill("jianxiong") {
+		t.Fatal("skill gained from another source should be kept when the hero is changed")
+	}
+	ctx.AssignHero(player, xiahoudun)
+	if !player.HasSkill("fankui") || player.HasSkill("jianxiong") || !player.HasSkill("ganglie") {
+		t.Fatal("assigned hero should replace only the skills of the previous heroes")
+	}
+}
+
+// testRepository keeps the modes built by the plugins loaded into it.
+type testRepository map[string]BuiltMode
+
+func (r testRepository) GetModeRegistration(name string) lsha.ModeRegistration { return r[name] }
+func (r testRepository) BuildMode(f func(builder lsha.ModeBuilder)) {
+	mode := BuildMode(f)
+	r[mode.GetName()] = mode
+}
+
+func TestHeroRegistry(t *testing.T) {
+	heroIDs := func(registration lsha.ModeRegistration) []string {
+		var ids []string
+		for _, def := range registration.HeroDefs() {
+			ids = append(ids, def.ID())
+		}
+		return ids
+	}
+	repository := testRepository{}
+	BuildPlugin(func(pb lsha.PluginBuilder) {
+		pb.Name("base").OnLoad(func(r lsha.ModeRepository) {
+			r.BuildMode(func(mb lsha.ModeBuilder) {
+				mb.Name("mode").ModeRegistration(func(registration lsha.ModeRegistration) {
+					registration.SetHeroDef(lsha.NewHeroDef("caocao", "曹操", lsha.KingdomWei, lsha.GenderMale, 4))
+					registration.SetHeroDef(lsha.NewHeroDef("liubei", "刘备", lsha.KingdomShu, lsha.GenderMale, 4))
+					registration.SetHeroDef(lsha.NewHeroDef("", "", lsha.KingdomWu, lsha.GenderMale, 4))
+					registration.SetHeroDef(nil)
+				})
+			})
+		})
+	}).Load(repository)
+	registration := repository.GetModeRegistration("mode")
+	if want := "[caocao liubei]"; fmt.Sprint(heroIDs(registration)) != want {
+		t.Fatalf("heroes = %v, want %s, heroes without id should be ignored", heroIDs(registration), want)
+	}
+
+	override := lsha.NewHeroDef("caocao", "曹操", lsha.KingdomWei, lsha.GenderMale, 3)
+	BuildPlugin(func(pb lsha.PluginBuilder) {
+		pb.Name("extension").Dependencies(map[string]int{"base": 0}).OnLoad(func(r lsha.ModeRepository) {
+			registration := r.GetModeRegistration("mode")
+			registration.SetHeroDef(override)
+			registration.SetHeroDef(lsha.NewHeroDef("sunquan", "孙权", lsha.KingdomWu, lsha.GenderMale, 4))
+			registration.DeleteHeroDef("liubei")
+			registration.DeleteHeroDef("unknown")
+		})
+	}).Load(repository)
+	defs := registration.HeroDefs()
+	if want := "[caocao sunquan]"; fmt.Sprint(heroIDs(registration)) != want {
+		t.Fatalf("heroes = %v, want %s", heroIDs(registration), want)
+	}
+	if defs[0] != override {
+		t.Fatal("the definition of the second plugin should override the one with the same id")
+	}
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func (c *runtimeContext) SetDelayedTrick(trick lsha.DelayedTrick) {
+	if trick == nil || trick.Name() == "" {
+		return
+	}
+	c.delayedTricks.Store(trick.Name(), trick)
+}
+
+func (c *runtimeContext) DelayedTrick(card lsha.Card) lsha.DelayedTrick {
+	if card == nil || card.Type() != lsha.CardTypeDelayedTrick {
+		return nil
+	}
+	if v, ok := c.delayedTricks.Load(card.Name()); ok {
+		return v.(lsha.DelayedTrick)
+	}
+	return nil
+}
+
+func (c *Context) Judge(player lsha.Player, reason string) lsha.Card {
+	if player == nil {
+		return nil
+	}
+	if c.drawPile.Len() == 0 {
+		c.reshuffle()
+	}
+	if c.drawPile.Len() == 0 {
+		return nil
+	}
+	card := c.drawPile.cards[0]
+	c.MoveCards(c.processing, lsha.MoveReasonJudge, card)
+	event := &lsha.JudgmentResultEvent{}
+	event.SetPlayer(player)
+	event.SetCard(card)
+	event.SetReason(reason)
+	c.Invoke(event)
+	result := event.Card()
+	c.discardProcessing(lsha.MoveReasonJudge, result)
+	return result
+}
+
+// ReplaceJudgment puts card as the new judgment card, the old one is discarded unless it was taken away.
+func (c *Context) ReplaceJudgment(event *lsha.JudgmentResultEvent, card lsha.Card) {
+	if event == nil || card == nil {
+		return
+	}
+	old := event.Card()
+	c.MoveCards(c.processing, lsha.MoveReasonJudge, card)
+	if old != nil {
+		c.discardProcessing(lsha.MoveReasonJudge, old)
+	}
+	event.SetCard(card)
+}
+
+// PlaceDelayedTrick puts card into the judgment area of player, a converted card stays there as the trick it
+// was converted into.
+func (c *Context) PlaceDelayedTrick(player lsha.Player, card lsha.Card) (placed bool) {
+	p, ok := player.(*Player)
+	if !ok || !p.IsAlive() || c.DelayedTrick(card) == nil {
+		return false
+	}
+	zone := p.zones[lsha.ZoneJudge]
+	for _, existing := range zone.Cards() {
+		if existing.Name() == card.Name() {
+			return false
+		}
+	}
+	c.MoveCards(zone, lsha.MoveReasonDelay, card)
+	if !zone.holds(card) {
+		return false
+	}
+	if virtual, ok := card.(*VirtualCard); ok {
+		zone.placeAs(virtual)
+	}
+	return true
+}
+
+// ResolveDelayedTricks resolves the delayed tricks of player from the latest placed one,
+// each of them may be nullified by cards matching nullification first.
+func (c *Context) ResolveDelayedTricks(player lsha.Player, nullification lsha.CardFilter) {
+	p, ok := player.(*Player)
+	if !ok {
+		return
+	}
+	zone := p.zones[lsha.ZoneJudge]
+	cards := zone.Cards()
+	for i := len(cards) - 1; i >= 0 && p.IsAlive(); i-- {
+		card := cards[i]
+		trick := c.DelayedTrick(card)
+		if trick == nil || !zone.holds(card) {
+			continue
+		}
+		c.MoveCards(c.processing, lsha.MoveReasonJudge, card)
+		event := &lsha.DelayedTrickEvent{}
+		event.SetPlayer(p)
+		event.SetCard(card)
+		c.Invoke(event)
+		effective := nullification == nil || c.ResolveNullification(event, nullification)
+		if effective {
+			effective = trick.Effective(c.Judge(p, trick.Name()))
+		}
+		trick.Resolve(c, p, card, effective)
+		c.discardProcessing(lsha.MoveReasonJudge, card)
+	}
+}
+
+// discardProcessing discards the parts of card which are still being processed.
+func (c *Context) discardProcessing(reason string, card lsha.Card) {
+	var remained []lsha.Card
+	for _, p := range physicalCards([]lsha.Card{card}) {
+		if p.zone == c.processing {
+			remained = append(remained, p)
+		}
+	}
+	c.MoveCards(c.discardPile, reason, remained...)
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// testDelayedTrick takes effect unless the judgment is of suit, a missed passing trick moves to the next player.
+type testDelayedTrick struct {
+	name     string
+	suit     lsha.Suit
+	passing  bool
+	resolved *[]string
+}
+
+func (t *testDelayedTrick) Name() string { return t.name }
+func (t *testDelayedTrick) Effective(judgment lsha.Card) bool {
+	return judgment != nil && judgment.Suit() != t.suit
+}
+func (t *testDelayedTrick) Resolve(ctx lsha.Context, player lsha.Player, trick lsha.Card, effective bool) {
+	*t.resolved = append(*t.resolved, fmt.Sprintf("%s:%s:%v", player.User().ID(), trick.Name(), effective))
+	if !effective && t.passing {
+		ctx.PlaceDelayedTrick(ctx.NextPlayer(player), trick)
+	}
+}
+
+func TestJudgment(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	a := (*ctx.players.Load())[0]
+	top := ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitSpade, 2)
+	replacement := ctx.NewCard("slash", lsha.CardTypeBasic, lsha.SuitHeart, 7)
+	ctx.MoveCards(a.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, replacement)
+	var reason string
+	ctx.AddTrigger(&testTrigger{name: "replace", invoke: func(ctx lsha.Context) {
+		event := ctx.Event().(*lsha.JudgmentResultEvent)
+		reason = event.Reason()
+		ctx.(*Context).ReplaceJudgment(event, replacement)
+	}}, a, lsha.EventJudgmentResult)
+	if result := ctx.Judge(a, "test"); result != replacement || reason != "test" {
+		t.Fatalf("judgment = %v, reason = %q, want the replacement", result, reason)
+	}
+	if ctx.discardPile.Len() != 2 || cardZone(top) != ctx.discardPile || cardZone(replacement) != ctx.discardPile {
+		t.Fatal("both judgment cards should be discarded")
+	}
+}
+
+func TestDelayedTricks(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	a, b := players[0], players[1]
+	var resolved []string
+	ctx.SetDelayedTrick(&testDelayedTrick{name: "indulgence", suit: lsha.SuitHeart, resolved: &resolved})
+	ctx.SetDelayedTrick(&testDelayedTrick{name: "shortage", suit: lsha.SuitClub, resolved: &resolved})
+	ctx.SetDelayedTrick(&testDelayedTrick{name: "lightning", suit: lsha.SuitHeart, passing: true, resolved: &resolved})
+	indulgence := ctx.NewCard("indulgence", lsha.CardTypeDelayedTrick, lsha.SuitSpade, 6)
+	source := ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitDiamond, 3)
+	lightning := ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitSpade, 1)
+	ctx.MoveCards(a.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, source, lightning)
+	for _, suit := range []lsha.Suit{lsha.SuitHeart, lsha.SuitClub, lsha.SuitDiamond} {
+		ctx.NewCard("judgment", lsha.CardTypeBasic, suit, 5)
+	}
+
+	shortage := ctx.NewVirtualCard("shortage", lsha.CardTypeDelayedTrick, source)
+	if !ctx.PlaceDelayedTrick(a, indulgence) || !ctx.PlaceDelayedTrick(a, shortage) {
+		t.Fatal("delayed tricks should be placed")
+	}
+	if ctx.PlaceDelayedTrick(a, ctx.NewVirtualCard("shortage", lsha.CardTypeDelayedTrick, lightning)) {
+		t.Fatal("the same delayed trick should not be placed twice")
+	}
+	if cards := a.Zone(lsha.ZoneJudge).Cards(); len(cards) != 2 || cards[1] != shortage {
+		t.Fatalf("judgment area = %v, the converted card should be listed as the trick", cards)
+	}
+	ctx.PlaceDelayedTrick(a, ctx.NewVirtualCard("lightning", lsha.CardTypeDelayedTrick, lightning))
+
+	ctx.ResolveDelayedTricks(a, nil)
+	if want := "[a:lightning:false a:shortage:false a:indulgence:true]"; fmt.Sprint(resolved) != want {
+		t.Fatalf("resolved = %v, want %s", resolved, want)
+	}
+	if a.Zone(lsha.ZoneJudge).Len() != 0 || cardZone(source) != ctx.discardPile || cardZone(indulgence) != ctx.discardPile {
+		t.Fatal("resolved tricks should be discarded")
+	}
+	if cards := b.Zone(lsha.ZoneJudge).Cards(); len(cards) != 1 || cards[0].Name() != "lightning" || cardZone(lightning) != b.zones[lsha.ZoneJudge] {
+		t.Fatalf("judgment area of b = %v, the missed lightning should be passed on", cards)
+	}
+}
+
+func cardZone(card lsha.Card) *Zone {
+	return card.(*Card).zone
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type mark struct {
+	name       string
+	count      int
+	visibility lsha.Visibility
+}
+
+func (m *mark) Name() string                { return m.name }
+func (m *mark) Count() int                  { return m.count }
+func (m *mark) Visibility() lsha.Visibility { return m.visibility }
+
+// marks keeps the marks of a holder in the order they were added.
+type marks struct {
+	holder lsha.MarkHolder
+	list   []*mark
+}
+
+func (m *marks) get(name string) *mark {
+	for _, mk := range m.list {
+		if mk.name == name {
+			return mk
+		}
+	}
+	return nil
+}
+
+func (m *marks) Count(name string) int {
+	if mk := m.get(name); mk != nil {
+		return mk.count
+	}
+	return 0
+}
+
+func (m *marks) List() []lsha.Mark {
+	list := make([]lsha.Mark, len(m.list))
+	for i, mk := range m.list {
+		list[i] = mk
+	}
+	return list
+}
+
+func (m *marks) Visible(viewer lsha.Player) []lsha.Mark {
+	var owner lsha.Player
+	if m.holder != nil {
+		owner = m.holder.MarkOwner()
+	}
+	var list []lsha.Mark
+	for _, mk := range m.list {
+		if mk.visibility.VisibleTo(owner, viewer) {
+			list = append(list, mk)
+		}
+	}
+	return list
+}
+
+func (p *Player) Marks() lsha.Marks      { return &p.marks }
+func (p *Player) MarkOwner() lsha.Player { return p }
+
+func (c *Card) Marks() lsha.Marks { return &c.marks }
+func (c *Card) MarkOwner() lsha.Player {
+	if c.zone == nil {
+		return nil
+	}
+	return c.zone.Owner()
+}
+
+func (c *VirtualCard) Marks() lsha.Marks      { return &c.marks }
+func (c *VirtualCard) MarkOwner() lsha.Player { return nil }
+
+func holderMarks(holder lsha.MarkHolder) *marks {
+	switch h := holder.(type) {
+	case *Player:
+		return &h.marks
+	case *Card:
+		return &h.marks
+	case *VirtualCard:
+		return &h.marks
+	}
+	return nil
+}
+
+func (c *Context) AddMark(holder lsha.MarkHolder, name string, n int, visibility lsha.Visibility) {
+	m := holderMarks(holder)
+	if m == nil || n <= 0 {
+		return
+	}
+	mk := m.get(name)
+	if mk == nil {
+		mk = &mark{name: name}
+		m.list = append(m.list, mk)
+	}
+	mk.visibility = visibility
+	mk.count += n
+	c.markChanged(holder, name, mk.count-n, mk.count)
+}
+
+func (c *Context) RemoveMark(holder lsha.MarkHolder, name string, n int) int {
+	m := holderMarks(holder)
+	if m == nil {
+		return 0
+	}
+	for i, mk := range m.list {
+		if mk.name != name {
+			continue
+		}
+		if n <= 0 || n >= mk.count {
+			n = mk.count
+			m.list = append(m.list[:i], m.list[i+1:]...)
+		}
+		mk.count -= n
+		c.markChanged(holder, name, mk.count+n, mk.count)
+		return n
+	}
+	return 0
+}
+
+func (c *Context) clearMarks(holder lsha.MarkHolder) {
+	m := holderMarks(holder)
+	for m != nil && len(m.list) > 0 {
+		c.RemoveMark(holder, m.list[0].name, 0)
+	}
+}
+
+func (c *Context) markChanged(holder lsha.MarkHolder, name string, old, count int) {
+	event := &lsha.MarkChangedEvent{}
+	event.SetHolder(holder)
+	event.SetMark(name)
+	event.SetOld(old)
+	event.SetCount(count)
+	c.Invoke(event)
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestMarks(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	var changes []int
+	ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+		changes = append(changes, ctx.Event().(*lsha.MarkChangedEvent).Count())
+	}}, nil, lsha.EventMarkChanged)
+	card := ctx.NewCard("test", lsha.CardTypeBasic, lsha.SuitSpade, 1)
+	ctx.MoveCards(players[0].Zone(lsha.ZoneHand), lsha.MoveReasonDraw, card)
+	ctx.AddMark(card, "seal", 2, lsha.VisibilityOwner)
+	if len(card.Marks().Visible(players[0])) != 1 || len(card.Marks().Visible(players[1])) != 0 {
+		t.Fatal("card mark should only be visible to the holding player")
+	}
+	ctx.AddMark(players[1], "fury", 3, lsha.VisibilityPublic)
+	if removed := ctx.RemoveMark(players[1], "fury", 1); removed != 1 || players[1].Marks().Count("fury") != 2 {
+		t.Fatalf("removed %d, left %d", removed, players[1].Marks().Count("fury"))
+	}
+	if removed := ctx.RemoveMark(players[1], "fury", 0); removed != 2 || len(players[1].Marks().List()) != 0 {
+		t.Fatalf("removed %d, left %v", removed, players[1].Marks().List())
+	}
+	ctx.MoveCards(ctx.discardPile, lsha.MoveReasonDiscard, card)
+	ctx.MoveCards(players[1].Zone(lsha.ZoneHand), lsha.MoveReasonDraw, card)
+	if len(card.Marks().List()) != 0 {
+		t.Fatal("card marks should be cleared when the card changes zone")
+	}
+	if want := []int{2, 3, 2, 0, 0}; fmt.Sprint(changes) != fmt.Sprint(want) {
+		t.Fatalf("changes = %v, want %v", changes, want)
+	}
+}
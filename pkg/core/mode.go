@@ -1,7 +1,7 @@
 package core
 
 import (
-	"math/rand"
+	"sort"
 
 	"github.com/ohanan/LambdaSha/pkg/core/common"
 	"github.com/ohanan/LambdaSha/pkg/core/form"
@@ -13,6 +13,7 @@ type BuiltMode interface {
 	GetName() string
 	GetDescription() string
 	GetPlayerCountLimit() (min, max int)
+	GetTeamCount() int
 	ValidateUser(user lsha.User) (reason string)
 	CreateConfigBuilder() (configData any, creator func(readonly bool) []*form.Item)
 	Run(configData any, users []lsha.User)
@@ -35,6 +36,9 @@ func newModeBuilder() *modeBuilder {
 		buildConfigFunc: func(roomConfigBuilder lsha.ConfigBuilder) {},
 		initializer:     func(ctx lsha.Context, builders []lsha.ModeInitUserBuilder) (ctxData any) { return nil },
 		nextTurn:        func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) { return nil },
+		heroDefs:        map[string]lsha.HeroDef{},
+		combinedMaxHP:   lsha.CombinedMaxHP,
+		skillDefs:       map[string]lsha.SkillDef{},
 	}
 }
 
@@ -45,6 +49,10 @@ type modeBuilder struct {
 	buildConfigFunc lsha.ModeRoomConfigBuilder
 	initializer     lsha.ModeInitializer
 	nextTurn        lsha.TurnStarter
+	heroDefs        map[string]lsha.HeroDef
+	combinedMaxHP   func(primary, secondary lsha.HeroDef) int
+	skillDefs       map[string]lsha.SkillDef
+	heroSelection   *heroSelectionBuilder
 }
 
 func (b *modeBuilder) GetName() string {
@@ -53,6 +61,9 @@ func (b *modeBuilder) GetName() string {
 func (b *modeBuilder) GetPlayerCountLimit() (min, max int) {
 	return b.userConfig.playerMinCount, b.userConfig.playerMaxCount
 }
+func (b *modeBuilder) GetTeamCount() int {
+	return b.userConfig.teams
+}
 func (b *modeBuilder) ValidateUser(user lsha.User) (reason string) {
 	return b.userConfig.userValidator(user)
 }
@@ -106,6 +117,15 @@ func (b *modeBuilder) NextTurn(f lsha.TurnStarter) lsha.ModeBuilder {
 	}
 	return b
 }
+func (b *modeBuilder) HeroSelection(builder func(builder lsha.ModeHeroSelectionBuilder)) lsha.ModeBuilder {
+	if b.heroSelection == nil {
+		b.heroSelection = &heroSelectionBuilder{candidates: defaultHeroCandidates}
+	}
+	if builder != nil {
+		builder(b.heroSelection)
+	}
+	return b
+}
 func (b *modeBuilder) ModeRegistration(f func(registration lsha.ModeRegistration)) lsha.ModeBuilder {
 	if f != nil {
 		f(b)
@@ -113,13 +133,42 @@ func (b *modeBuilder) ModeRegistration(f func(registration lsha.ModeRegistration
 	return b
 }
 func (b *modeBuilder) SetHeroDef(h lsha.HeroDef) {
-	// TODO implement me
-	panic("implement me")
+	if h == nil || h.ID() == "" {
+		return
+	}
+	b.heroDefs[h.ID()] = h
+}
+
+func (b *modeBuilder) DeleteHeroDef(id string) {
+	delete(b.heroDefs, id)
+}
+
+func (b *modeBuilder) HeroDefs() []lsha.HeroDef {
+	defs := make([]lsha.HeroDef, 0, len(b.heroDefs))
+	for _, def := range b.heroDefs {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].ID() < defs[j].ID()
+	})
+	return defs
+}
+
+func (b *modeBuilder) SetCombinedMaxHP(f func(primary, secondary lsha.HeroDef) int) {
+	if f != nil {
+		b.combinedMaxHP = f
+	}
+}
+
+func (b *modeBuilder) SetSkillDef(s lsha.SkillDef) {
+	if s == nil || s.Name() == "" {
+		return
+	}
+	b.skillDefs[s.Name()] = s
 }
 
-func (b *modeBuilder) DeleteHeroDef(name string) {
-	// TODO implement me
-	panic("implement me")
+func (b *modeBuilder) DeleteSkillDef(name string) {
+	delete(b.skillDefs, name)
 }
 
 func (b *modeBuilder) Description(description string) lsha.ModeBuilder {
@@ -134,7 +183,7 @@ func (b *modeBuilder) Run(configData any, users []lsha.User) {
 		users = copied
 	}
 	if !b.userConfig.disableRandomOrder {
-		rand.Shuffle(len(users), func(i, j int) {
+		ctx.rand.Shuffle(len(users), func(i, j int) {
 			users[i], users[j] = users[j], users[i]
 		})
 	}
@@ -144,27 +193,40 @@ func (b *modeBuilder) Run(configData any, users []lsha.User) {
 		initBuilders[i] = &ModeInitUserBuilder{
 			user:  user,
 			order: i,
+			team:  -1,
+		}
+		if member, ok := user.(lsha.TeamMember); ok && b.userConfig.teams > 0 {
+			initBuilders[i].(*ModeInitUserBuilder).team = member.Team()
 		}
 	}
 	ctx.data.Store(common.Ptr(b.initializer(ctx, initBuilders)))
 	players := make([]*Player, len(users))
 	for i, builder := range initBuilders {
 		b := builder.(*ModeInitUserBuilder)
-		players[i] = &Player{
-			data:  b.data,
-			order: b.order,
-			user:  b.user,
-		}
+		players[i] = newPlayer(b.order, b.user, b.data)
+		players[i].role = b.role
+		players[i].lord = b.lord
+		players[i].team = b.team
 	}
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].order < players[j].order
+	})
 	ctx.players.Store(common.Ptr(players))
 	for _, player := range players {
 		event := &lsha.PlayerPreparedEvent{}
 		event.SetPlayer(player)
 		ctx.Invoke(event)
 	}
+	if b.heroSelection != nil {
+		b.heroSelection.run(ctx)
+	}
 	ctx.Invoke(&lsha.GameStartedEvent{})
-	for i := 2024; i > 0; i-- {
+	for i := 2024; i > 0 && !ctx.IsGameOver(); i-- {
 		lastTurn := ctx.turn.Load()
+		if lastTurn != nil && lastTurn.player == nil {
+			// the empty turn before the first one
+			lastTurn = nil
+		}
 		tb := &TurnBuilder{}
 		turn := &Turn{}
 		turn.data = b.nextTurn(ctx, tb)
@@ -174,12 +236,23 @@ func (b *modeBuilder) Run(configData any, users []lsha.User) {
 		turn.player = tb.player
 		turn.round = tb.round
 		if turn.round <= 0 {
-			turn.round = lastTurn.round + 1
+			// a new round starts when the seat order wraps around
+			turn.round = 1
+			if lastTurn != nil {
+				turn.round = lastTurn.round
+				if turn.player.Order() <= lastTurn.player.Order() {
+					turn.round++
+				}
+			}
+		}
+		if lastTurn != nil && turn.round > lastTurn.round {
+			ctx.tickEffects(lsha.DurationRounds, nil)
 		}
 		ctx.turn.Store(turn)
 		turnStartedEvent := &lsha.TurnStartedEvent{}
+		turnStartedEvent.SetTurn(turn)
 		ctx.Invoke(turnStartedEvent)
-		for j := 100; j > 0; j-- {
+		for j := 100; j > 0 && !ctx.IsGameOver(); j-- {
 			pb := &PhaseBuilder{}
 			phase := &Phase{}
 			phase.data = tb.nextPhase(ctx, pb)
@@ -193,6 +266,7 @@ func (b *modeBuilder) Run(configData any, users []lsha.User) {
 			phaseStartedEvent.SetTurn(turn)
 			ctx.Invoke(phaseStartedEvent)
 		}
+		ctx.tickEffects(lsha.DurationTurns, turn.player.(*Player))
 	}
 }
 
@@ -201,6 +275,7 @@ type modeConfigBuilder struct {
 	playerMaxCount     int
 	userValidator      func(account lsha.User) (reason string)
 	disableRandomOrder bool
+	teams              int
 }
 
 func (m *modeConfigBuilder) MinPlayer(playerCount int) lsha.ModeUserConfigBuilder {
@@ -230,11 +305,20 @@ func (m *modeConfigBuilder) DisableRandomOrder() lsha.ModeUserConfigBuilder {
 	m.disableRandomOrder = true
 	return m
 }
+func (m *modeConfigBuilder) Teams(count int) lsha.ModeUserConfigBuilder {
+	if count > 1 {
+		m.teams = count
+	}
+	return m
+}
 
 type ModeInitUserBuilder struct {
 	user  lsha.User
 	order int
 	data  any
+	role  lsha.Role
+	lord  bool
+	team  int
 }
 
 func (m *ModeInitUserBuilder) User() lsha.User {
@@ -250,6 +334,21 @@ func (m *ModeInitUserBuilder) RewriteOrder(order int) lsha.ModeInitUserBuilder {
 	return m
 }
 
+func (m *ModeInitUserBuilder) Role(role lsha.Role, lord bool) lsha.ModeInitUserBuilder {
+	m.role = role
+	m.lord = lord
+	return m
+}
+
+func (m *ModeInitUserBuilder) Team() int {
+	return m.team
+}
+
+func (m *ModeInitUserBuilder) RewriteTeam(team int) lsha.ModeInitUserBuilder {
+	m.team = team
+	return m
+}
+
 func (m *ModeInitUserBuilder) BindData(data any) lsha.ModeInitUserBuilder {
 	m.data = data
 	return m
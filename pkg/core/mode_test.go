@@ -0,0 +1,100 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestRunTurns(t *testing.T) {
+	var started []string
+	var expired []string
+	turns := 0
+	mode := BuildMode(func(mb lsha.ModeBuilder) {
+		mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+			builder.DisableRandomOrder()
+		}).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+			ctx.AddTrigger(&testTrigger{name: "effects", invoke: func(ctx lsha.Context) {
+				var players []lsha.Player
+				ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+					players = append(players, p)
+					return true
+				})
+				ctx.AddEffect(players[0], lsha.NewEffect("round"), 1, lsha.Rounds(1))
+				ctx.AddEffect(players[1], lsha.NewEffect("turn"), 1, lsha.Turns(1))
+			}}, nil, lsha.EventGameStarted)
+			ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+				turn := ctx.Event().(*lsha.TurnStartedEvent).Turn()
+				started = append(started, fmt.Sprintf("%s%d", turn.Player().User().ID(), turn.Round()))
+			}}, nil, lsha.EventTurnStarted)
+			ctx.AddTrigger(&testTrigger{name: "expired", invoke: func(ctx lsha.Context) {
+				expired = append(expired, ctx.Event().(*lsha.EffectRemovedEvent).Effect().Effect().Name())
+			}}, nil, lsha.EventEffectRemoved)
+			return nil
+		}).NextTurn(func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+			if turns++; turns > 3 {
+				return nil
+			}
+			phases := 0
+			tb.Player(ctx.NextPlayer(nil)).OnNextPhase(func(ctx lsha.Context, pb lsha.PhaseBuilder) (phaseData any) {
+				if phases++; phases <= 2 {
+					pb.Name(fmt.Sprint("phase", phases))
+				}
+				return nil
+			})
+			return nil
+		})
+	})
+	mode.Run(nil, []lsha.User{&testUser{id: "a"}, &testUser{id: "b"}})
+	if want := "[a1 b1 a2]"; fmt.Sprint(started) != want {
+		t.Fatalf("started turns = %v, want %s", started, want)
+	}
+	if want := "[turn round]"; fmt.Sprint(expired) != want {
+		t.Fatalf("expired effects = %v, want %s", expired, want)
+	}
+}
+
+func TestSeatOrder(t *testing.T) {
+	var seats []string
+	var turns []string
+	mode := BuildMode(func(mb lsha.ModeBuilder) {
+		mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+			builder.DisableRandomOrder()
+		}).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+			// the players sit in the reverse order of the users
+			for i, builder := range userBuilders {
+				builder.RewriteOrder(len(userBuilders) - 1 - i)
+			}
+			ctx.AddTrigger(&testTrigger{name: "seats", invoke: func(ctx lsha.Context) {
+				var first lsha.Player
+				ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+					if first == nil {
+						first = p
+					}
+					seats = append(seats, p.User().ID())
+					return true
+				})
+				ctx.Kill(first, nil)
+			}}, nil, lsha.EventGameStarted)
+			return nil
+		}).NextTurn(func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+			if len(turns) == 3 {
+				ctx.EndGame()
+			}
+			player := ctx.NextPlayer(nil)
+			turns = append(turns, player.User().ID())
+			tb.Player(player).OnNextPhase(func(ctx lsha.Context, pb lsha.PhaseBuilder) (phaseData any) {
+				return nil
+			})
+			return nil
+		})
+	})
+	mode.Run(nil, []lsha.User{&testUser{id: "a"}, &testUser{id: "b"}, &testUser{id: "c"}})
+	if want := "[c b a]"; fmt.Sprint(seats) != want {
+		t.Fatalf("seats = %v, want %s", seats, want)
+	}
+	if want := "[b a b a]"; fmt.Sprint(turns) != want {
+		t.Fatalf("turns = %v, want %s, the first alive seat should start and dead players should be skipped", turns, want)
+	}
+}
@@ -0,0 +1,20 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// ResolveNullification keeps asking players in seat order from the current turn player
+// to nullify the last effect until everyone passes, each nullification flips the result.
+func (c *Context) ResolveNullification(respondTo lsha.Event, filter lsha.CardFilter) (resolved bool) {
+	resolved = true
+	last := respondTo
+	for {
+		event := c.respondCardInOrder(nil, last, filter, nil)
+		if event == nil {
+			return resolved
+		}
+		resolved = !resolved
+		last = event
+	}
+}
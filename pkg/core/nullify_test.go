@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/core/common"
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type testUser struct {
+	id    string
+	reply func(request lsha.Request) any
+}
+
+func (u *testUser) ID() string { return u.id }
+
+func (u *testUser) Respond(player lsha.Player, request lsha.Request) <-chan any {
+	ch := make(chan any, 1)
+	if u.reply != nil {
+		ch <- u.reply(request)
+	} else {
+		ch <- nil
+	}
+	return ch
+}
+
+func playFirstCard(request lsha.Request) any {
+	if r, ok := request.(*lsha.CardRequest); ok && len(r.Cards()) > 0 {
+		return r.Cards()[:1]
+	}
+	return nil
+}
+
+func newTestContext(users ...*testUser) *Context {
+	ctx := newContext(newModeBuilder(), nil, nil)
+	players := make([]*Player, len(users))
+	for i, user := range users {
+		players[i] = newPlayer(i, user, nil)
+	}
+	ctx.players.Store(common.Ptr(players))
+	ctx.turn.Store(&Turn{player: players[0]})
+	return ctx
+}
+
+func TestResolveNullification(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []int
+		resolved bool
+	}{
+		{name: "nobody nullifies", cards: []int{0, 0, 0}, resolved: true},
+		{name: "nullified once", cards: []int{0, 1, 0}, resolved: false},
+		{name: "nullification nullified", cards: []int{1, 0, 1}, resolved: true},
+		{name: "three nullifications", cards: []int{2, 1, 0}, resolved: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := make([]*testUser, len(tt.cards))
+			for i := range users {
+				users[i] = &testUser{id: string(rune('a' + i)), reply: playFirstCard}
+			}
+			ctx := newTestContext(users...)
+			for i, n := range tt.cards {
+				player := (*ctx.players.Load())[i]
+				for j := 0; j < n; j++ {
+					ctx.MoveCards(player.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, ctx.NewCard("nullification", lsha.CardTypeTrick, lsha.SuitSpade, 11))
+				}
+			}
+			var responded []string
+			ctx.AddTrigger(&testTrigger{name: "record", eventName: lsha.EventCardResponded, invoke: func(ctx lsha.Context) {
+				responded = append(responded, ctx.Event().(*lsha.CardRespondedEvent).Player().User().ID())
+			}}, nil, lsha.EventCardResponded)
+			if resolved := ctx.ResolveNullification(&lsha.GameStartedEvent{}, lsha.CardNamed("nullification")); resolved != tt.resolved {
+				t.Fatalf("resolved = %v, want %v, responded: %v", resolved, tt.resolved, responded)
+			}
+			if ctx.discardPile.Len() != len(responded) {
+				t.Fatalf("discard pile has %d cards, want %d", ctx.discardPile.Len(), len(responded))
+			}
+		})
+	}
+}
+
+type testTrigger struct {
+	name      string
+	eventName string
+	priority  float64
+	invoke    func(ctx lsha.Context)
+}
+
+func (t *testTrigger) Name() string      { return t.name }
+func (t *testTrigger) EventName() string { return t.eventName }
+func (t *testTrigger) Priority() float64 { return t.priority }
+func (t *testTrigger) Invoke(ctx lsha.Context, enter bool, result lsha.InvokeResult) {
+	if enter {
+		t.invoke(ctx)
+	}
+}
@@ -5,10 +5,47 @@ import (
 )
 
 type Player struct {
-	data  any
-	order int
-	user  lsha.User
-	dead  bool
+	data    any
+	order   int
+	user    lsha.User
+	dead    bool
+	hp      int
+	maxHP   int
+	chained bool
+	heroes  [2]*Hero
+	kingdom lsha.Kingdom
+	role    lsha.Role
+	// roleVisibility tells who may see the role, only the owner by default.
+	roleVisibility lsha.Visibility
+	lord           bool
+	team           int
+	skills         []*playerSkill
+	states         map[string]lsha.SkillState
+	zones          map[lsha.ZoneType]*Zone
+	piles          []*Zone
+	equips         map[lsha.EquipSlot]*equippedCard
+	effects        effects
+	marks          marks
+}
+
+func newPlayer(order int, user lsha.User, data any) *Player {
+	p := &Player{
+		data:  data,
+		order: order,
+		user:  user,
+		team:  -1,
+
+		roleVisibility: lsha.VisibilityOwner,
+	}
+	p.zones = map[lsha.ZoneType]*Zone{
+		lsha.ZoneHand:  newZone(lsha.ZoneHand, p),
+		lsha.ZoneEquip: newZone(lsha.ZoneEquip, p),
+		lsha.ZoneJudge: newZone(lsha.ZoneJudge, p),
+	}
+	p.equips = map[lsha.EquipSlot]*equippedCard{}
+	p.states = map[string]lsha.SkillState{}
+	p.marks.holder = p
+	return p
 }
 
 func (p *Player) BindData(data any) {
@@ -31,7 +68,59 @@ func (p *Player) IsAlive() bool {
 	return !p.dead
 }
 
-func (p *Player) Effects() lsha.Effect {
-	// TODO implement me
-	panic("implement me")
+func (p *Player) Effects() lsha.Effects {
+	return &p.effects
+}
+
+func (p *Player) Zone(zoneType lsha.ZoneType) lsha.Zone {
+	if z, ok := p.zones[zoneType]; ok {
+		return z
+	}
+	return nil
+}
+
+func (p *Player) pile(name string) *Zone {
+	for _, pile := range p.piles {
+		if pile.name == name {
+			return pile
+		}
+	}
+	return nil
+}
+
+func (p *Player) Pile(name string) lsha.Zone {
+	if pile := p.pile(name); pile != nil {
+		return pile
+	}
+	return nil
+}
+
+func (p *Player) Piles() []lsha.Zone {
+	piles := make([]lsha.Zone, len(p.piles))
+	for i, pile := range p.piles {
+		piles[i] = pile
+	}
+	return piles
+}
+
+func (p *Player) Equipment(slot lsha.EquipSlot) lsha.Card {
+	if e, ok := p.equips[slot]; ok {
+		return e.card
+	}
+	return nil
+}
+
+func (p *Player) Hero() lsha.Hero {
+	return p.heroAt(lsha.HeroPrimary)
+}
+
+func (p *Player) SecondaryHero() lsha.Hero {
+	return p.heroAt(lsha.HeroSecondary)
+}
+
+func (p *Player) heroAt(slot lsha.HeroSlot) lsha.Hero {
+	if slot < 0 || int(slot) >= len(p.heroes) || p.heroes[slot] == nil {
+		return nil
+	}
+	return p.heroes[slot]
 }
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"time"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func (c *runtimeContext) Ask(player lsha.Player, request lsha.Request) (reply any) {
+	if player == nil || !player.IsAlive() {
+		return nil
+	}
+	responder, ok := player.User().(lsha.Responder)
+	if !ok {
+		return nil
+	}
+	ch := responder.Respond(player, request)
+	if ch == nil {
+		return nil
+	}
+	timer := time.NewTimer(request.Timeout())
+	defer timer.Stop()
+	select {
+	case reply = <-ch:
+	case <-timer.C:
+		return nil
+	}
+	if reply == nil || !request.Accept(reply) {
+		return nil
+	}
+	return reply
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func (c *Context) RespondCard(player lsha.Player, respondTo lsha.Event, filter lsha.CardFilter) lsha.Card {
+	if event := c.respondCard(player, respondTo, filter); event != nil {
+		return event.Card()
+	}
+	return nil
+}
+
+func (c *Context) RespondCardInOrder(start lsha.Player, respondTo lsha.Event, filter lsha.CardFilter, responders lsha.PlayerFilter) (lsha.Player, lsha.Card) {
+	if event := c.respondCardInOrder(start, respondTo, filter, responders); event != nil {
+		return event.Player(), event.Card()
+	}
+	return nil, nil
+}
+
+func (c *Context) respondCardInOrder(start lsha.Player, respondTo lsha.Event, filter lsha.CardFilter, responders lsha.PlayerFilter) (event *lsha.CardRespondedEvent) {
+	lsha.FilterPlayers(c, start, responders)(func(player lsha.Player) bool {
+		event = c.respondCard(player, respondTo, filter)
+		return event == nil
+	})
+	return
+}
+
+func (c *Context) respondCard(player lsha.Player, respondTo lsha.Event, filter lsha.CardFilter) *lsha.CardRespondedEvent {
+	if player == nil || !player.IsAlive() {
+		return nil
+	}
+	request := c.cardRequest(lsha.RequestRespondCard, player, filter)
+	if !c.hasCandidates(request) {
+		return nil
+	}
+	card := c.replyCard(player, c.Ask(player, request), filter)
+	if card == nil {
+		return nil
+	}
+	c.MoveCards(c.discardPile, lsha.MoveReasonRespond, card)
+	event := &lsha.CardRespondedEvent{}
+	event.SetPlayer(player)
+	event.SetCard(card)
+	event.SetRespondTo(respondTo)
+	c.Invoke(event)
+	return event
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// silentUser never answers, the request times out quickly.
+type silentUser struct {
+	id string
+}
+
+func (u *silentUser) ID() string { return u.id }
+
+func (u *silentUser) Respond(player lsha.Player, request lsha.Request) <-chan any {
+	request.(*lsha.CardRequest).SetTimeout(time.Millisecond)
+	return make(chan any)
+}
+
+func TestRespondCard(t *testing.T) {
+	tests := []struct {
+		name string
+		// reply is the name of the card replied, an empty name passes
+		reply  string
+		silent bool
+		want   string
+	}{
+		{name: "passed"},
+		{name: "timed out", silent: true},
+		{name: "rejected by the filter", reply: "slash"},
+		{name: "responded", reply: "dodge", want: "dodge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hand []lsha.Card
+			var user lsha.User = &testUser{id: "a", reply: func(request lsha.Request) any {
+				for _, card := range hand {
+					if card.Name() == tt.reply {
+						return []lsha.Card{card}
+					}
+				}
+				return nil
+			}}
+			if tt.silent {
+				user = &silentUser{id: "a"}
+			}
+			ctx := newContext(newModeBuilder(), nil, nil)
+			a := newPlayer(0, user, nil)
+			ctx.players.Store(&[]*Player{a})
+			ctx.turn.Store(&Turn{player: a})
+			slash := ctx.NewCard("slash", lsha.CardTypeBasic, lsha.SuitSpade, 1)
+			dodge := ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitHeart, 2)
+			hand = []lsha.Card{slash, dodge}
+			ctx.MoveCards(a.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, hand...)
+			var responded []*lsha.CardRespondedEvent
+			ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+				responded = append(responded, ctx.Event().(*lsha.CardRespondedEvent))
+			}}, nil, lsha.EventCardResponded)
+			respondTo := &lsha.GameStartedEvent{}
+
+			card := ctx.RespondCard(a, respondTo, lsha.CardNamed("dodge"))
+			if tt.want == "" {
+				if card != nil || len(responded) != 0 || a.Zone(lsha.ZoneHand).Len() != 2 {
+					t.Fatalf("responded %v, nothing should be responded", card)
+				}
+				return
+			}
+			if card != dodge || cardZone(dodge) != ctx.discardPile || cardZone(slash) != a.Zone(lsha.ZoneHand) {
+				t.Fatalf("responded %v, the dodge should be responded and discarded", card)
+			}
+			if len(responded) != 1 || responded[0].Player() != a || responded[0].Card() != dodge || responded[0].RespondTo() != respondTo {
+				t.Fatal("the response should invoke CardResponded")
+			}
+		})
+	}
+}
+
+func TestRespondCardInOrder(t *testing.T) {
+	var asked []string
+	ask := func(id string, respond bool) *testUser {
+		return &testUser{id: id, reply: func(request lsha.Request) any {
+			asked = append(asked, id)
+			if respond {
+				return request.(*lsha.CardRequest).Cards()[:1]
+			}
+			return nil
+		}}
+	}
+	ctx := newTestContext(ask("a", true), ask("b", true), ask("c", false), ask("d", true), ask("e", true))
+	players := *ctx.players.Load()
+	a, b, d, e := players[0], players[1], players[3], players[4]
+	for _, p := range []*Player{a, b, players[2], e} {
+		ctx.MoveCards(p.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitHeart, 2))
+	}
+	// c passes, d has no dodge to respond, e is not a responder, then a responds and b is never asked
+	player, card := ctx.RespondCardInOrder(players[2], &lsha.GameStartedEvent{}, lsha.CardNamed("dodge"), lsha.Other(e))
+	if player != a || card == nil || cardZone(card) != ctx.discardPile {
+		t.Fatalf("responded by %v, want a", player)
+	}
+	if want := "[c a]"; fmt.Sprint(asked) != want {
+		t.Fatalf("asked = %v, want %s", asked, want)
+	}
+	if b.Zone(lsha.ZoneHand).Len() != 1 || d.Zone(lsha.ZoneHand).Len() != 0 {
+		t.Fatal("only the responder should lose the card")
+	}
+}
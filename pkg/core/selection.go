@@ -0,0 +1,121 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+const defaultHeroCandidates = 3
+
+type heroSelectionBuilder struct {
+	candidates  int
+	firstPicker func(ctx lsha.Context) lsha.Player
+	selector    func(ctx lsha.Context) bool
+}
+
+func (h *heroSelectionBuilder) Candidates(count int) lsha.ModeHeroSelectionBuilder {
+	if count > 0 {
+		h.candidates = count
+	}
+	return h
+}
+
+func (h *heroSelectionBuilder) FirstPicker(f func(ctx lsha.Context) lsha.Player) lsha.ModeHeroSelectionBuilder {
+	h.firstPicker = f
+	return h
+}
+
+func (h *heroSelectionBuilder) Selector(f func(ctx lsha.Context) (handled bool)) lsha.ModeHeroSelectionBuilder {
+	h.selector = f
+	return h
+}
+
+// run deals candidates from the shuffled hero pool, the first picker picks and reveals the hero
+// before the others pick simultaneously.
+func (h *heroSelectionBuilder) run(ctx *Context) {
+	if h.selector != nil && h.selector(ctx) {
+		return
+	}
+	pool := ctx.HeroDefs()
+	ctx.rand.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	deal := func() []lsha.HeroDef {
+		n := min(h.candidates, len(pool))
+		dealt := pool[:n:n]
+		pool = pool[n:]
+		return dealt
+	}
+	var first lsha.Player
+	if h.firstPicker != nil {
+		first = h.firstPicker(ctx)
+	}
+	if first != nil {
+		ctx.selectHero(first, ctx.AskHero(first, deal()))
+	}
+	var others []lsha.Player
+	var candidates [][]lsha.HeroDef
+	for _, player := range *ctx.players.Load() {
+		if player != first && player.IsAlive() {
+			others = append(others, player)
+			candidates = append(candidates, deal())
+		}
+	}
+	for i, def := range ctx.askHeroes(others, candidates) {
+		ctx.selectHero(others[i], def)
+	}
+}
+
+func (c *Context) selectHero(player lsha.Player, def lsha.HeroDef) {
+	hero := c.AssignHero(player, def)
+	if hero == nil {
+		return
+	}
+	event := &lsha.HeroSelectedEvent{}
+	event.SetPlayer(player)
+	event.SetHero(hero)
+	c.Invoke(event)
+}
+
+// AskHero asks player to pick one of candidates, a random one is picked if the player does not answer.
+func (c *Context) AskHero(player lsha.Player, candidates []lsha.HeroDef) lsha.HeroDef {
+	return c.askHeroes([]lsha.Player{player}, [][]lsha.HeroDef{candidates})[0]
+}
+
+// askHeroes asks players to pick their heroes simultaneously.
+func (c *Context) askHeroes(players []lsha.Player, candidates [][]lsha.HeroDef) []lsha.HeroDef {
+	replies := make([]any, len(players))
+	var wg sync.WaitGroup
+	for i, player := range players {
+		if len(candidates[i]) == 0 {
+			continue
+		}
+		ids := make([]string, len(candidates[i]))
+		for j, def := range candidates[i] {
+			ids[j] = def.ID()
+		}
+		wg.Add(1)
+		go func(i int, player lsha.Player, request lsha.Request) {
+			defer wg.Done()
+			replies[i] = c.Ask(player, request)
+		}(i, player, lsha.NewOptionRequest(lsha.RequestSelectHero, ids))
+	}
+	wg.Wait()
+	picked := make([]lsha.HeroDef, len(players))
+	for i, reply := range replies {
+		if len(candidates[i]) == 0 {
+			continue
+		}
+		for _, def := range candidates[i] {
+			if def.ID() == reply {
+				picked[i] = def
+				break
+			}
+		}
+		if picked[i] == nil {
+			picked[i] = candidates[i][c.rand.Intn(len(candidates[i]))]
+		}
+	}
+	return picked
+}
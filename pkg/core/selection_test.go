@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestHeroSelection(t *testing.T) {
+	var mu sync.Mutex
+	var asked []string
+	options := map[string][]string{}
+	pick := func(id string, choose func(options []string) any) func(request lsha.Request) any {
+		return func(request lsha.Request) any {
+			offered := request.(*lsha.OptionRequest).Options()
+			mu.Lock()
+			asked = append(asked, id)
+			options[id] = offered
+			mu.Unlock()
+			return choose(offered)
+		}
+	}
+	last := func(options []string) any { return options[len(options)-1] }
+	cAsked := make(chan struct{})
+	var simultaneous bool
+	users := []lsha.User{
+		&testUser{id: "a", reply: pick("a", func(options []string) any {
+			select {
+			case <-cAsked:
+				simultaneous = true
+			case <-time.After(time.Second):
+			}
+			return last(options)
+		})},
+		&testUser{id: "b", reply: pick("b", last)},
+		&testUser{id: "c", reply: pick("c", func(options []string) any {
+			close(cAsked)
+			return "unknown"
+		})},
+	}
+	selected := map[string]string{}
+	var order []string
+	BuildMode(func(mb lsha.ModeBuilder) {
+		mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+			builder.DisableRandomOrder()
+		}).ModeRegistration(func(registration lsha.ModeRegistration) {
+			for i := 1; i <= 9; i++ {
+				registration.SetHeroDef(lsha.NewHeroDef(fmt.Sprint("hero", i), "", lsha.KingdomWei, lsha.GenderMale, 4))
+			}
+		}).HeroSelection(func(builder lsha.ModeHeroSelectionBuilder) {
+			builder.FirstPicker(func(ctx lsha.Context) lsha.Player {
+				return ctx.NextPlayer(ctx.NextPlayer(nil))
+			})
+		}).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+			ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+				event := ctx.Event().(*lsha.HeroSelectedEvent)
+				id := event.Player().User().ID()
+				order = append(order, id)
+				selected[id] = event.Hero().Def().ID()
+			}}, nil, lsha.EventHeroSelected)
+			return nil
+		})
+	}).Run(nil, users)
+
+	if len(asked) != 3 || asked[0] != "b" || len(order) != 3 || order[0] != "b" {
+		t.Fatalf("asked %v, selected %v, the first picker should pick first", asked, order)
+	}
+	if !simultaneous {
+		t.Fatal("the other players should pick simultaneously")
+	}
+	dealt := map[string]struct{}{}
+	for _, id := range []string{"a", "b", "c"} {
+		for _, option := range options[id] {
+			dealt[option] = struct{}{}
+		}
+	}
+	if len(dealt) != 9 {
+		t.Fatalf("candidates %v should not overlap", options)
+	}
+	if selected["a"] != last(options["a"]) || selected["b"] != last(options["b"]) {
+		t.Fatalf("selected %v, the answered heroes should be selected", selected)
+	}
+	var fallback bool
+	for _, option := range options["c"] {
+		fallback = fallback || selected["c"] == option
+	}
+	if !fallback {
+		t.Fatalf("selected %v, a random candidate should be selected for an invalid answer", selected)
+	}
+}
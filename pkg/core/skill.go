@@ -0,0 +1,241 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type playerSkill struct {
+	def          lsha.SkillDef
+	triggerIDs   []uint64
+	converterIDs []uint64
+	modifierIDs  []uint64
+	// hero tells the skill was gained from a hero and is lost with it.
+	hero bool
+}
+
+func (c *runtimeContext) SkillDef(name string) lsha.SkillDef {
+	return c.modeBuilder.skillDefs[name]
+}
+
+func (p *Player) Skills() []lsha.SkillDef {
+	skills := make([]lsha.SkillDef, len(p.skills))
+	for i, skill := range p.skills {
+		skills[i] = skill.def
+	}
+	return skills
+}
+
+func (p *Player) HasSkill(name string) bool {
+	return p.skill(name) != nil
+}
+
+func (p *Player) SkillState(name string) lsha.SkillState {
+	return p.states[name]
+}
+
+func (p *Player) skill(name string) *playerSkill {
+	for _, skill := range p.skills {
+		if skill.def.Name() == name {
+			return skill
+		}
+	}
+	return nil
+}
+
+// addSkill registers the triggers, converters and modifiers of def for p.
+func (c *runtimeContext) addSkill(p *Player, def lsha.SkillDef) bool {
+	if def == nil || p.skill(def.Name()) != nil {
+		return false
+	}
+	skill := &playerSkill{def: def}
+	for _, trigger := range def.Triggers() {
+		if id := c.AddTrigger(trigger, p, trigger.EventName()); id > 0 {
+			skill.triggerIDs = append(skill.triggerIDs, id)
+		}
+	}
+	for _, converter := range def.Converters() {
+		if id := c.AddConverter(converter, p); id > 0 {
+			skill.converterIDs = append(skill.converterIDs, id)
+		}
+	}
+	for _, modifier := range def.Modifiers() {
+		if id := c.AddDistanceModifier(modifier, p); id > 0 {
+			skill.modifierIDs = append(skill.modifierIDs, id)
+		}
+	}
+	p.skills = append(p.skills, skill)
+	return true
+}
+
+func (c *runtimeContext) removeSkill(p *Player, name string) bool {
+	for i, skill := range p.skills {
+		if skill.def.Name() != name {
+			continue
+		}
+		for _, id := range skill.triggerIDs {
+			c.RemoveTrigger(id)
+		}
+		for _, id := range skill.converterIDs {
+			c.RemoveConverter(id)
+		}
+		for _, id := range skill.modifierIDs {
+			c.RemoveDistanceModifier(id)
+		}
+		p.skills = append(p.skills[:i], p.skills[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// GainSkill gives the skill to player, a skill already gained from a hero is kept when the hero is lost.
+func (c *Context) GainSkill(player lsha.Player, name string) bool {
+	p, ok := player.(*Player)
+	if !ok {
+		return false
+	}
+	return c.gainSkill(p, name, false)
+}
+
+func (c *Context) gainSkill(p *Player, name string, hero bool) bool {
+	if skill := p.skill(name); skill != nil {
+		skill.hero = skill.hero && hero
+		return false
+	}
+	if !c.addSkill(p, c.SkillDef(name)) {
+		return false
+	}
+	p.skill(name).hero = hero
+	event := &lsha.SkillGainedEvent{}
+	event.SetPlayer(p)
+	event.SetSkill(c.SkillDef(name))
+	c.Invoke(event)
+	return true
+}
+
+func (c *Context) LoseSkill(player lsha.Player, name string) bool {
+	p, ok := player.(*Player)
+	if !ok {
+		return false
+	}
+	skill := p.skill(name)
+	if skill == nil || !c.removeSkill(p, name) {
+		return false
+	}
+	event := &lsha.SkillLostEvent{}
+	event.SetPlayer(p)
+	event.SetSkill(skill.def)
+	c.Invoke(event)
+	return true
+}
+
+func (c *Context) AwakenSkill(player lsha.Player, name string) bool {
+	p, ok := player.(*Player)
+	if !ok {
+		return false
+	}
+	skill := p.skill(name)
+	if skill == nil || !skill.def.Tags().Has(lsha.SkillTagAwakening) || p.states[name] == lsha.SkillAwakened {
+		return false
+	}
+	p.states[name] = lsha.SkillAwakened
+	event := &lsha.SkillAwakenedEvent{}
+	event.SetPlayer(p)
+	event.SetSkill(skill.def)
+	c.Invoke(event)
+	return true
+}
+
+func (c *Context) SpendSkill(player lsha.Player, name string) bool {
+	p, ok := player.(*Player)
+	if !ok {
+		return false
+	}
+	skill := p.skill(name)
+	if skill == nil || !skill.def.Tags().Has(lsha.SkillTagLimited) || p.states[name] == lsha.SkillSpent {
+		return false
+	}
+	p.states[name] = lsha.SkillSpent
+	event := &lsha.SkillSpentEvent{}
+	event.SetPlayer(p)
+	event.SetSkill(skill.def)
+	c.Invoke(event)
+	return true
+}
+
+func (c *Context) usableSkill(p *Player, name string) lsha.ActiveSkill {
+	skill := p.skill(name)
+	if skill == nil {
+		return nil
+	}
+	if skill.def.Tags().Has(lsha.SkillTagLimited) && p.states[name] == lsha.SkillSpent {
+		return nil
+	}
+	active := skill.def.Active()
+	if active == nil || !active.Usable(c, p) {
+		return nil
+	}
+	return active
+}
+
+func (c *Context) UseSkill(player lsha.Player, skill string, cards []lsha.Card, targets []lsha.Player) (used bool) {
+	p, ok := player.(*Player)
+	if !ok || !p.IsAlive() {
+		return false
+	}
+	active := c.usableSkill(p, skill)
+	if active == nil || !active.Use(c, p, cards, targets) {
+		return false
+	}
+	c.SpendSkill(p, skill)
+	event := &lsha.SkillUsedEvent{}
+	event.SetPlayer(p)
+	event.SetSkill(skill)
+	event.SetCards(cards)
+	event.SetTargets(targets)
+	c.Invoke(event)
+	return true
+}
+
+// AskPlay asks player to use a card matching filter or a usable active skill, nil means the player passed.
+// Only the alive players matching targets can be chosen, a nil targets allows any player.
+// The card of the choice is nil if the chosen conversion failed.
+func (c *Context) AskPlay(player lsha.Player, filter lsha.CardFilter, targets lsha.PlayerFilter) *lsha.PlayChoice {
+	p, ok := player.(*Player)
+	if !ok || !p.IsAlive() {
+		return nil
+	}
+	request := lsha.NewUseCardRequest(c.cardRequest(lsha.RequestUseCard, p, filter))
+	if targets != nil {
+		request.SetTargets(lsha.Players(c, p, targets))
+	}
+	for _, skill := range p.skills {
+		if c.usableSkill(p, skill.def.Name()) != nil {
+			request.AddSkill(skill.def.Name())
+		}
+	}
+	if len(request.Skills()) > 0 {
+		request.SetSkillCards(append(p.zones[lsha.ZoneHand].Cards(), p.zones[lsha.ZoneEquip].Cards()...))
+	}
+	if !c.hasCandidates(request.CardRequest) && len(request.Skills()) == 0 {
+		return nil
+	}
+	reply, _ := c.Ask(p, request).(*lsha.UseCardReply)
+	if reply == nil {
+		return nil
+	}
+	choice := &lsha.PlayChoice{
+		Skill:   reply.Skill,
+		Cards:   reply.Cards,
+		Targets: reply.Targets,
+	}
+	if reply.Skill != "" {
+		return choice
+	}
+	if reply.Convert != nil {
+		choice.Card = c.replyCard(p, reply.Convert, filter)
+		choice.Cards = reply.Convert.Sources
+	} else {
+		choice.Card = c.replyCard(p, reply.Cards, filter)
+	}
+	return choice
+}
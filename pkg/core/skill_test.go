@@ -0,0 +1,184 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type testActiveSkill struct {
+	usable bool
+	used   int
+}
+
+func (s *testActiveSkill) Usable(ctx lsha.Context, player lsha.Player) bool { return s.usable }
+func (s *testActiveSkill) Use(ctx lsha.Context, player lsha.Player, cards []lsha.Card, targets []lsha.Player) bool {
+	s.used++
+	return len(targets) == 1
+}
+
+func TestSkills(t *testing.T) {
+	var invoked int
+	active := &testActiveSkill{}
+	var offered []string
+	ctx := newTestContext(&testUser{id: "a", reply: func(request lsha.Request) any {
+		if r, ok := request.(*lsha.UseCardRequest); ok {
+			offered = r.Skills()
+		}
+		return nil
+	}}, &testUser{id: "b"}, &testUser{id: "c"}, &testUser{id: "d"})
+	players := *ctx.players.Load()
+	a, c := players[0], players[2]
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("mashu").
+		Trigger(&testTrigger{name: "mashu", eventName: lsha.EventChainChanged, invoke: func(ctx lsha.Context) { invoked++ }}).
+		Converter(&testConverter{}).
+		Modifier(&testDistanceModifier{delta: -1}).
+		Activate(active))
+	if ctx.GainSkill(a, "unknown") {
+		t.Fatal("undefined skill should not be gained")
+	}
+	if !ctx.GainSkill(a, "mashu") || ctx.GainSkill(a, "mashu") {
+		t.Fatal("skill should be gained once")
+	}
+	ctx.ToggleChained(a)
+	if invoked != 1 || len(ctx.playerConverters(a)) != 1 || ctx.Distance(a, c) != 1 {
+		t.Fatalf("skill should register its trigger, converter and modifier, invoked: %d", invoked)
+	}
+
+	if ctx.AskPlay(a, nil, nil) != nil || len(offered) != 0 {
+		t.Fatalf("unusable skill should not be offered, offered: %v", offered)
+	}
+	active.usable = true
+	ctx.AskPlay(a, nil, nil)
+	if len(offered) != 1 || offered[0] != "mashu" {
+		t.Fatalf("offered = %v, want [mashu]", offered)
+	}
+	var used string
+	ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+		used = ctx.Event().(*lsha.SkillUsedEvent).Skill()
+	}}, nil, lsha.EventSkillUsed)
+	if ctx.UseSkill(a, "mashu", nil, nil) || used != "" {
+		t.Fatal("skill refused by Use should not be used")
+	}
+	if !ctx.UseSkill(a, "mashu", nil, []lsha.Player{c}) || used != "mashu" || active.used != 2 {
+		t.Fatalf("skill should be used, used: %q", used)
+	}
+	if ctx.UseSkill(c, "mashu", nil, []lsha.Player{a}) {
+		t.Fatal("skill should only be used by its owner")
+	}
+
+	if !ctx.LoseSkill(a, "mashu") || a.HasSkill("mashu") {
+		t.Fatal("skill should be lost")
+	}
+	ctx.ToggleChained(a)
+	if invoked != 1 || len(ctx.playerConverters(a)) != 0 || ctx.Distance(a, c) != 2 {
+		t.Fatal("lost skill should unregister its trigger, converter and modifier")
+	}
+}
+
+func TestSkillStates(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"})
+	players := *ctx.players.Load()
+	a, b := players[0], players[1]
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("luanwu").Tag(lsha.SkillTagLimited).Activate(&testActiveSkill{usable: true}))
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("hunzi").Tag(lsha.SkillTagAwakening))
+	var events []string
+	ctx.AddTrigger(&testTrigger{name: "spent", invoke: func(ctx lsha.Context) {
+		events = append(events, "spent:"+ctx.Event().(*lsha.SkillSpentEvent).Skill().Name())
+	}}, nil, lsha.EventSkillSpent)
+	ctx.AddTrigger(&testTrigger{name: "awakened", invoke: func(ctx lsha.Context) {
+		events = append(events, "awakened:"+ctx.Event().(*lsha.SkillAwakenedEvent).Skill().Name())
+	}}, nil, lsha.EventSkillAwakened)
+	ctx.GainSkill(a, "luanwu")
+	ctx.GainSkill(a, "hunzi")
+
+	if ctx.AwakenSkill(a, "luanwu") || ctx.SpendSkill(a, "hunzi") || ctx.SpendSkill(b, "luanwu") {
+		t.Fatal("only limited skills should be spent and only awakening skills awakened by their owner")
+	}
+	if !ctx.UseSkill(a, "luanwu", nil, []lsha.Player{b}) || a.SkillState("luanwu") != lsha.SkillSpent {
+		t.Fatal("limited skill should be spent once used")
+	}
+	if ctx.SpendSkill(a, "luanwu") || ctx.UseSkill(a, "luanwu", nil, []lsha.Player{b}) {
+		t.Fatal("spent skill should not be spent or used again")
+	}
+	if !ctx.AwakenSkill(a, "hunzi") || ctx.AwakenSkill(a, "hunzi") {
+		t.Fatal("skill should be awakened once")
+	}
+	ctx.LoseSkill(a, "luanwu")
+	ctx.LoseSkill(a, "hunzi")
+	ctx.GainSkill(a, "luanwu")
+	ctx.GainSkill(a, "hunzi")
+	if a.SkillState("luanwu") != lsha.SkillSpent || a.SkillState("hunzi") != lsha.SkillAwakened {
+		t.Fatal("skill states should be kept after the skills are lost and gained again")
+	}
+	if want := "[spent:luanwu awakened:hunzi]"; fmt.Sprint(events) != want {
+		t.Fatalf("events = %v, want %s", events, want)
+	}
+}
+
+func TestPlayTargets(t *testing.T) {
+	var target lsha.Player
+	var skill string
+	var skillCards []lsha.Card
+	var offered []lsha.Player
+	ctx := newTestContext(&testUser{id: "a", reply: func(request lsha.Request) any {
+		r := request.(*lsha.UseCardRequest)
+		offered = r.Targets()
+		if skill != "" {
+			return &lsha.UseCardReply{Skill: skill, Cards: skillCards, Targets: []lsha.Player{target}}
+		}
+		return &lsha.UseCardReply{Cards: r.Cards()[:1], Targets: []lsha.Player{target}}
+	}}, &testUser{id: "b"}, &testUser{id: "c"})
+	players := *ctx.players.Load()
+	a, b := players[0], players[1]
+	players[2].dead = true
+	ctx.MoveCards(a.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, ctx.NewCard("slash", lsha.CardTypeBasic, lsha.SuitSpade, 1))
+	ctx.modeBuilder.SetSkillDef(lsha.NewSkillDef("mashu").Activate(&testActiveSkill{usable: true}))
+	ctx.GainSkill(a, "mashu")
+
+	target = a
+	if ctx.AskPlay(a, nil, lsha.Other(a)) != nil {
+		t.Fatal("a target not matching the filter should be refused")
+	}
+	if len(offered) != 1 || offered[0] != b {
+		t.Fatalf("offered targets = %v, want only b", offered)
+	}
+	if choice := ctx.AskPlay(a, nil, nil); choice == nil || choice.Card == nil {
+		t.Fatal("any target should be accepted without a filter")
+	}
+	skill = "mashu"
+	if ctx.AskPlay(a, nil, lsha.Other(a)) != nil {
+		t.Fatal("a skill target not matching the filter should be refused")
+	}
+	target = b
+	if choice := ctx.AskPlay(a, nil, lsha.Other(a)); choice == nil || choice.Skill != "mashu" {
+		t.Fatal("a skill target matching the filter should be accepted")
+	}
+	skillCards = []lsha.Card{ctx.NewCard("dodge", lsha.CardTypeBasic, lsha.SuitHeart, 2)}
+	ctx.MoveCards(b.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, skillCards...)
+	if ctx.AskPlay(a, nil, lsha.Other(a)) != nil {
+		t.Fatal("a skill card of another player should be refused")
+	}
+	ctx.MoveCards(a.Zone(lsha.ZoneJudge), lsha.MoveReasonDelay, skillCards...)
+	if ctx.AskPlay(a, nil, lsha.Other(a)) != nil {
+		t.Fatal("a skill card outside the hand and the equipment should be refused")
+	}
+	ctx.MoveCards(a.Zone(lsha.ZoneHand), lsha.MoveReasonDraw, skillCards...)
+	if choice := ctx.AskPlay(a, nil, lsha.Other(a)); choice == nil || len(choice.Cards) != 1 || choice.Cards[0] != skillCards[0] {
+		t.Fatal("a skill card in the hand should be accepted")
+	}
+	ctx.MoveCards(ctx.discardPile, lsha.MoveReasonDiscard, skillCards...)
+	skillCards = nil
+
+	card := a.Zone(lsha.ZoneHand).Cards()[0]
+	if ctx.UseCard(a, card, lsha.Other(a), a) || ctx.UseCard(a, card, lsha.Alive(), players[2]) || ctx.UseCard(a, card, nil, nil) {
+		t.Fatal("card should not be used on invalid targets")
+	}
+	if cardZone(card) != a.Zone(lsha.ZoneHand) {
+		t.Fatal("card used on invalid targets should stay in hand")
+	}
+	if !ctx.UseCard(a, card, lsha.Other(a), b) || cardZone(card) != ctx.discardPile {
+		t.Fatal("card should be used on a valid target")
+	}
+}
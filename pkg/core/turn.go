@@ -30,6 +30,9 @@ func (t *Turn) Round() int {
 }
 
 func (t *Turn) Phase() lsha.Phase {
+	if t.phase == nil {
+		return nil
+	}
 	return t.phase
 }
 
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// UseCard uses card on targets, it is not used if any target does not match filter.
+func (c *Context) UseCard(player lsha.Player, card lsha.Card, filter lsha.PlayerFilter, targets ...lsha.Player) (used bool) {
+	if player == nil || card == nil || !player.IsAlive() {
+		return false
+	}
+	for _, target := range targets {
+		if target == nil || filter != nil && !filter(target) {
+			return false
+		}
+	}
+	c.MoveCards(c.processing, lsha.MoveReasonUse, card)
+	defer c.discardProcessing(lsha.MoveReasonUse, card)
+	using := &lsha.CardUsingEvent{}
+	using.SetPlayer(player)
+	using.SetCard(card)
+	using.SetTargets(targets)
+	c.Invoke(using)
+	if using.Cancelled() {
+		return false
+	}
+	event := &lsha.CardUsedEvent{}
+	event.SetPlayer(player)
+	event.SetCard(card)
+	event.SetTargets(using.Targets())
+	c.Invoke(event)
+	switch card.Type() {
+	case lsha.CardTypeEquipment:
+		c.Equip(player, card)
+	case lsha.CardTypeDelayedTrick:
+		if targets := event.Targets(); len(targets) > 0 {
+			c.PlaceDelayedTrick(targets[0], card)
+		}
+	}
+	return true
+}
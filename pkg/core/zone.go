@@ -0,0 +1,226 @@
+package core
+
+import (
+	"math/rand"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+var _ lsha.Zone = (*Zone)(nil)
+
+type Zone struct {
+	zoneType        lsha.ZoneType
+	name            string
+	visibility      lsha.Visibility
+	countVisibility lsha.Visibility
+	owner           lsha.Player
+	cards           []*Card
+	// placedAs keeps the virtual cards whose sources are placed in the zone, such as converted delayed tricks.
+	placedAs map[*Card]*VirtualCard
+}
+
+func newZone(zoneType lsha.ZoneType, owner lsha.Player) *Zone {
+	z := &Zone{
+		zoneType: zoneType,
+		owner:    owner,
+	}
+	switch zoneType {
+	case lsha.ZoneDrawPile:
+		z.visibility = lsha.VisibilityHidden
+	case lsha.ZoneHand:
+		z.visibility = lsha.VisibilityOwner
+	}
+	return z
+}
+
+func (c *runtimeContext) NewPile(player lsha.Player, name string, visibility lsha.Visibility) lsha.Zone {
+	p, ok := player.(*Player)
+	if !ok {
+		return nil
+	}
+	if pile := p.pile(name); pile != nil {
+		return pile
+	}
+	pile := newZone(lsha.ZonePile, p)
+	pile.name = name
+	pile.visibility = visibility
+	p.piles = append(p.piles, pile)
+	return pile
+}
+
+func (z *Zone) Type() lsha.ZoneType {
+	return z.zoneType
+}
+
+func (z *Zone) Owner() lsha.Player {
+	return z.owner
+}
+
+func (z *Zone) Name() string {
+	return z.name
+}
+
+func (z *Zone) Visibility() lsha.Visibility {
+	return z.visibility
+}
+
+func (z *Zone) CountVisibility() lsha.Visibility {
+	return z.countVisibility
+}
+
+func (c *runtimeContext) SetZoneVisibility(zone lsha.Zone, cards, count lsha.Visibility) {
+	if z, ok := zone.(*Zone); ok {
+		z.visibility = cards
+		z.countVisibility = count
+	}
+}
+
+func (z *Zone) Cards() []lsha.Card {
+	cards := make([]lsha.Card, 0, len(z.cards))
+	listed := map[*VirtualCard]struct{}{}
+	for _, card := range z.cards {
+		virtual, ok := z.placedAs[card]
+		if !ok {
+			cards = append(cards, card)
+			continue
+		}
+		if _, ok = listed[virtual]; !ok {
+			listed[virtual] = struct{}{}
+			cards = append(cards, virtual)
+		}
+	}
+	return cards
+}
+
+func (z *Zone) Len() int {
+	return len(z.cards)
+}
+
+func (z *Zone) add(card *Card) {
+	card.zone = z
+	z.cards = append(z.cards, card)
+}
+
+func (z *Zone) remove(card *Card) {
+	for i, c := range z.cards {
+		if c == card {
+			copy(z.cards[i:], z.cards[i+1:])
+			z.cards[len(z.cards)-1] = nil
+			z.cards = z.cards[:len(z.cards)-1]
+			break
+		}
+	}
+	delete(z.placedAs, card)
+	card.zone = nil
+}
+
+// placeAs lists the sources of card in the zone as card.
+func (z *Zone) placeAs(card *VirtualCard) {
+	if z.placedAs == nil {
+		z.placedAs = map[*Card]*VirtualCard{}
+	}
+	for _, physical := range physicalCards(card.sources) {
+		z.placedAs[physical] = card
+	}
+}
+
+// holds reports whether all the physical cards of card are in the zone.
+func (z *Zone) holds(card lsha.Card) bool {
+	physical := physicalCards([]lsha.Card{card})
+	for _, p := range physical {
+		if p.zone != z {
+			return false
+		}
+	}
+	return len(physical) > 0
+}
+
+func (z *Zone) shuffle(r *rand.Rand) {
+	r.Shuffle(len(z.cards), func(i, j int) {
+		z.cards[i], z.cards[j] = z.cards[j], z.cards[i]
+	})
+}
+
+func (c *runtimeContext) Zone(zoneType lsha.ZoneType) lsha.Zone {
+	switch zoneType {
+	case lsha.ZoneDrawPile:
+		return c.drawPile
+	case lsha.ZoneDiscardPile:
+		return c.discardPile
+	case lsha.ZoneProcessing:
+		return c.processing
+	}
+	return nil
+}
+
+func (c *Context) MoveCards(to lsha.Zone, reason string, cards ...lsha.Card) {
+	target, ok := to.(*Zone)
+	if !ok || len(cards) == 0 {
+		return
+	}
+	var sources []*Zone
+	var marked []*Card
+	moved := map[*Zone][]lsha.Card{}
+	for _, card := range physicalCards(cards) {
+		if card.zone == target {
+			continue
+		}
+		from := card.zone
+		if from != nil {
+			if owner, ok := from.owner.(*Player); ok && from.zoneType == lsha.ZoneEquip {
+				c.unequip(owner, card)
+			}
+			from.remove(card)
+		}
+		if _, ok := moved[from]; !ok {
+			sources = append(sources, from)
+		}
+		moved[from] = append(moved[from], card)
+		target.add(card)
+		if len(card.marks.list) > 0 {
+			marked = append(marked, card)
+		}
+	}
+	// marks on a card do not follow it to another zone
+	for _, card := range marked {
+		c.clearMarks(card)
+	}
+	for _, from := range sources {
+		event := &lsha.CardsMovedEvent{}
+		event.SetCards(moved[from])
+		if from != nil {
+			event.SetFrom(from)
+		}
+		event.SetTo(target)
+		event.SetReason(reason)
+		c.Invoke(event)
+	}
+}
+
+func (c *Context) DrawCards(player lsha.Player, n int) []lsha.Card {
+	p, ok := player.(*Player)
+	if !ok || n <= 0 {
+		return nil
+	}
+	if c.drawPile.Len() < n {
+		c.reshuffle()
+	}
+	if c.drawPile.Len() < n {
+		n = c.drawPile.Len()
+	}
+	cards := make([]lsha.Card, n)
+	for i := range cards {
+		cards[i] = c.drawPile.cards[i]
+	}
+	c.MoveCards(p.zones[lsha.ZoneHand], lsha.MoveReasonDraw, cards...)
+	return cards
+}
+
+// reshuffle puts the discard pile under the draw pile.
+func (c *Context) reshuffle() {
+	if c.discardPile.Len() == 0 {
+		return
+	}
+	c.discardPile.shuffle(c.rand)
+	c.MoveCards(c.drawPile, lsha.MoveReasonShuffle, c.discardPile.Cards()...)
+}
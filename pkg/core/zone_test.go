@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestPiles(t *testing.T) {
+	ctx := newTestContext(&testUser{id: "a"}, &testUser{id: "b"}, &testUser{id: "c"})
+	players := *ctx.players.Load()
+	a, b := players[0], players[1]
+	var moved []*lsha.CardsMovedEvent
+	ctx.AddTrigger(&testTrigger{name: "record", invoke: func(ctx lsha.Context) {
+		moved = append(moved, ctx.Event().(*lsha.CardsMovedEvent))
+	}}, nil, lsha.EventCardsMoved)
+
+	owned := ctx.NewPile(a, "owned", lsha.VisibilityOwner)
+	public := ctx.NewPile(a, "public", lsha.VisibilityPublic)
+	hidden := ctx.NewPile(a, "hidden", lsha.VisibilityHidden)
+	if ctx.NewPile(a, "owned", lsha.VisibilityPublic) != owned || owned.Visibility() != lsha.VisibilityOwner {
+		t.Fatal("a pile should be reused by name and keep its visibility")
+	}
+	if ctx.NewPile(b, "owned", lsha.VisibilityOwner) == owned || a.Pile("public") != public || len(a.Piles()) != 3 {
+		t.Fatal("piles should belong to their owners")
+	}
+
+	tests := []struct {
+		pile     lsha.Zone
+		owner    bool
+		everyone bool
+	}{
+		{pile: owned, owner: true},
+		{pile: public, owner: true, everyone: true},
+		{pile: hidden},
+	}
+	for _, tt := range tests {
+		if lsha.ZoneVisibleTo(tt.pile, a) != tt.owner || lsha.ZoneVisibleTo(tt.pile, b) != tt.everyone {
+			t.Fatalf("visibility of %s is wrong", tt.pile.Name())
+		}
+		moved = nil
+		ctx.MoveCards(tt.pile, lsha.MoveReasonDraw, ctx.NewCard("slash", lsha.CardTypeBasic, lsha.SuitSpade, 1))
+		if len(moved) != 1 || moved[0].Visible(a) != tt.owner || moved[0].Visible(b) != tt.everyone {
+			t.Fatalf("cards moved into %s should be visible to the same players as the pile", tt.pile.Name())
+		}
+	}
+
+	ctx.Kill(a, nil)
+	for _, pile := range a.Piles() {
+		if pile.Len() != 0 {
+			t.Fatalf("%s should be emptied on death", pile.Name())
+		}
+	}
+	if ctx.discardPile.Len() != 3 {
+		t.Fatalf("discard pile has %d cards, want 3", ctx.discardPile.Len())
+	}
+}
@@ -0,0 +1,67 @@
+package lsha
+
+type (
+	CardFilter = func(card Card) bool
+)
+
+type Suit int
+
+const (
+	SuitNone Suit = iota
+	SuitSpade
+	SuitHeart
+	SuitClub
+	SuitDiamond
+)
+
+type Color int
+
+const (
+	ColorNone Color = iota
+	ColorBlack
+	ColorRed
+)
+
+type CardType int
+
+const (
+	CardTypeBasic CardType = iota + 1
+	CardTypeTrick
+	CardTypeDelayedTrick
+	CardTypeEquipment
+)
+
+type Card interface {
+	ID() uint64
+	Name() string
+	Type() CardType
+	Suit() Suit
+	Color() Color
+	Number() int
+	IsVirtual() bool
+	// Sources returns the physical cards backing a virtual card.
+	Sources() []Card
+	MarkHolder
+}
+
+// Converter lets a player use some cards as another card, such as a red card as Slash.
+type Converter interface {
+	Name() string
+	Candidates(ctx Context, player Player) []Card
+	// Convert returns nil if the sources can not be converted.
+	Convert(ctx Context, player Player, sources []Card) Card
+}
+
+func (s Suit) Color() Color {
+	switch s {
+	case SuitSpade, SuitClub:
+		return ColorBlack
+	case SuitHeart, SuitDiamond:
+		return ColorRed
+	}
+	return ColorNone
+}
+
+func CardNamed(name string) CardFilter {
+	return func(card Card) bool { return card.Name() == name }
+}
@@ -1,11 +1,64 @@
 package lsha
 
-import "iter"
+import (
+	"iter"
+	"math/rand"
+)
 
 type Context interface {
 	RuntimeContext
 	WithEvent(event Event) Context
 	Event() Event
+	Invoke(event Event)
+	MoveCards(to Zone, reason string, cards ...Card)
+	DrawCards(player Player, n int) []Card
+	RespondCard(player Player, respondTo Event, filter CardFilter) Card
+	// RespondCardInOrder asks the players matching responders in seat order until one responds.
+	RespondCardInOrder(start Player, respondTo Event, filter CardFilter, responders PlayerFilter) (Player, Card)
+	ResolveNullification(respondTo Event, filter CardFilter) (resolved bool)
+	// UseCard uses card on targets, it is not used if any target does not match filter.
+	UseCard(player Player, card Card, filter PlayerFilter, targets ...Player) (used bool)
+	// AskPlay asks player to use a card matching filter on the players matching targets, or a usable active skill.
+	AskPlay(player Player, filter CardFilter, targets PlayerFilter) *PlayChoice
+	UseSkill(player Player, skill string, cards []Card, targets []Player) (used bool)
+	Equip(player Player, card Card) (equipped bool)
+	Judge(player Player, reason string) Card
+	ReplaceJudgment(event *JudgmentResultEvent, card Card)
+	PlaceDelayedTrick(player Player, card Card) (placed bool)
+	ResolveDelayedTricks(player Player, nullification CardFilter)
+	Damage(damage *Damage) (dealt int)
+	Heal(heal *Heal) (healed int)
+	Kill(player Player, damage *Damage)
+	SetChained(player Player, chained bool)
+	ToggleChained(player Player)
+	HandLimit(player Player) int
+	Discard(player Player, cards ...Card)
+	AskDiscard(player Player, n int, filter CardFilter, forced bool) []Card
+	AssignHero(player Player, def HeroDef) Hero
+	// AssignDualHeroes gives two heroes to player, the max hp is combined by the mode.
+	AssignDualHeroes(player Player, primary, secondary HeroDef, revealed bool) (Hero, Hero)
+	RevealHero(player Player, slot HeroSlot) (revealed bool)
+	// ChangeHero transforms the hero in slot into def, the hp and max hp are kept.
+	ChangeHero(player Player, slot HeroSlot, def HeroDef) Hero
+	AskHero(player Player, candidates []HeroDef) HeroDef
+	// SetLord gains or loses the lord-only skills of the hero of player.
+	SetLord(player Player, lord bool)
+	// EndGame finishes the game after the running phase, only the first call takes effect.
+	EndGame(winners ...Player)
+	// AddEffect adds stacks to the effect of player and resets its duration.
+	AddEffect(player Player, effect Effect, stacks int, duration Duration) PlayerEffect
+	// RemoveEffect removes stacks of the effect, all stacks are removed if stacks is not positive.
+	RemoveEffect(player Player, name string, stacks int) (removed bool)
+	GainSkill(player Player, name string) (gained bool)
+	LoseSkill(player Player, name string) (lost bool)
+	// AwakenSkill marks the awakening skill as awakened, it returns false if the skill can not awaken.
+	AwakenSkill(player Player, name string) (awakened bool)
+	// SpendSkill marks the limited skill as spent, it returns false if the skill has been spent.
+	SpendSkill(player Player, name string) (spent bool)
+	// AddMark puts n marks on holder, the marks on a card are cleared when the card is moved to another zone.
+	AddMark(holder MarkHolder, name string, n int, visibility Visibility)
+	// RemoveMark removes n of the marks, all of them are removed if n is not positive.
+	RemoveMark(holder MarkHolder, name string, n int) (removed int)
 }
 type RuntimeContext interface {
 	BindData(data any)
@@ -13,10 +66,40 @@ type RuntimeContext interface {
 	RoomConfig() any
 	RuntimeConfig() ConfigBuilder
 	Turn() Turn
+	IsGameOver() bool
 	PlayerIter(start Player) iter.Seq[Player]
 	NextPlayer(start Player) Player
 	AddTrigger(trigger Trigger, player Player, eventNames ...string) (id uint64)
 	RemoveTrigger(id uint64)
+	Zone(zoneType ZoneType) Zone
+	// NewPile creates the named pile on player, the existing pile is returned if there is one.
+	NewPile(player Player, name string, visibility Visibility) Zone
+	SetZoneVisibility(zone Zone, cards, count Visibility)
+	NewCard(name string, cardType CardType, suit Suit, number int) Card
+	NewVirtualCard(name string, cardType CardType, sources ...Card) Card
+	AddConverter(converter Converter, player Player) (id uint64)
+	RemoveConverter(id uint64)
+	SetEquipment(equipment Equipment)
+	Equipment(card Card) Equipment
+	SetDelayedTrick(trick DelayedTrick)
+	DelayedTrick(card Card) DelayedTrick
+	SetMaxHP(player Player, maxHP int)
+	SetHP(player Player, hp int)
+	// SetKingdom changes the kingdom of player, an empty kingdom falls back to the kingdom of the hero.
+	SetKingdom(player Player, kingdom Kingdom)
+	// SetRoleVisibility changes who may see the role of player.
+	SetRoleVisibility(player Player, visibility Visibility)
+	HeroDefs() []HeroDef
+	HeroDef(id string) HeroDef
+	SkillDef(name string) SkillDef
+	// Rand is the random source of the game, it must only be used by the game goroutine.
+	Rand() *rand.Rand
+	AddDistanceModifier(modifier DistanceModifier, player Player) (id uint64)
+	RemoveDistanceModifier(id uint64)
+	Distance(from, to Player) int
+	AttackRange(player Player) int
+	InAttackRange(from, to Player) bool
+	Ask(player Player, request Request) (reply any)
 }
 type DataHolder interface {
 	BindData(data any)
@@ -31,9 +114,15 @@ func Data[V any](c DataHolder) (_ V) {
 	}
 	return
 }
+
+// KingdomPlayers iterates the other alive players of kingdom in seat order after player.
+func KingdomPlayers(ctx RuntimeContext, player Player, kingdom Kingdom) iter.Seq[Player] {
+	return FilterPlayers(ctx, player, MatchAll(Other(player), OfKingdom(kingdom)))
+}
+
 func TurnData[V any](ctx Context) (_ V) {
-	if v := ctx.Turn(); v != nil {
-		if v, ok := v.(V); ok {
+	if t := ctx.Turn(); t != nil {
+		if v, ok := t.Data().(V); ok {
 			return v
 		}
 	}
@@ -42,8 +131,8 @@ func TurnData[V any](ctx Context) (_ V) {
 
 func PhaseData[V any](ctx Context) (_ V) {
 	if t := ctx.Turn(); t != nil {
-		if v := t.Phase(); v != nil {
-			if v, ok := v.(V); ok {
+		if p := t.Phase(); p != nil {
+			if v, ok := p.Data().(V); ok {
 				return v
 			}
 		}
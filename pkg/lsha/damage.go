@@ -0,0 +1,76 @@
+package lsha
+
+type DamageNature int
+
+const (
+	DamageNormal DamageNature = iota
+	DamageFire
+	DamageThunder
+)
+
+type Damage struct {
+	source      Player
+	target      Player
+	amount      int
+	nature      DamageNature
+	card        Card
+	prevented   bool
+	chainedFrom *Damage
+}
+
+func NewDamage(source, target Player, amount int, nature DamageNature, card Card) *Damage {
+	return &Damage{
+		source: source,
+		target: target,
+		amount: amount,
+		nature: nature,
+		card:   card,
+	}
+}
+
+func (d *Damage) Source() Player                { return d.source }
+func (d *Damage) SetSource(source Player)       { d.source = source }
+func (d *Damage) Target() Player                { return d.target }
+func (d *Damage) SetTarget(target Player)       { d.target = target }
+func (d *Damage) Amount() int                   { return d.amount }
+func (d *Damage) SetAmount(amount int)          { d.amount = amount }
+func (d *Damage) Nature() DamageNature          { return d.nature }
+func (d *Damage) SetNature(nature DamageNature) { d.nature = nature }
+func (d *Damage) Card() Card                    { return d.card }
+func (d *Damage) SetCard(card Card)             { d.card = card }
+func (d *Damage) Prevented() bool               { return d.prevented || d.amount <= 0 }
+func (d *Damage) Prevent()                      { d.prevented = true }
+func (d *Damage) IsElemental() bool             { return d.nature != DamageNormal }
+
+// ChainedFrom returns the original elemental damage which this damage is propagated from.
+func (d *Damage) ChainedFrom() *Damage          { return d.chainedFrom }
+func (d *Damage) SetChainedFrom(damage *Damage) { d.chainedFrom = damage }
+func (d *Damage) IsChained() bool               { return d.chainedFrom != nil }
+
+type Heal struct {
+	source    Player
+	target    Player
+	amount    int
+	card      Card
+	prevented bool
+}
+
+func NewHeal(source, target Player, amount int, card Card) *Heal {
+	return &Heal{
+		source: source,
+		target: target,
+		amount: amount,
+		card:   card,
+	}
+}
+
+func (h *Heal) Source() Player          { return h.source }
+func (h *Heal) SetSource(source Player) { h.source = source }
+func (h *Heal) Target() Player          { return h.target }
+func (h *Heal) SetTarget(target Player) { h.target = target }
+func (h *Heal) Amount() int             { return h.amount }
+func (h *Heal) SetAmount(amount int)    { h.amount = amount }
+func (h *Heal) Card() Card              { return h.card }
+func (h *Heal) SetCard(card Card)       { h.card = card }
+func (h *Heal) Prevented() bool         { return h.prevented || h.amount <= 0 }
+func (h *Heal) Prevent()                { h.prevented = true }
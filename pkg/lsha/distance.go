@@ -0,0 +1,10 @@
+package lsha
+
+// DistanceModifier is folded in priority order over the seat distance and the attack range,
+// owner is the player it is registered for.
+type DistanceModifier interface {
+	Name() string
+	Priority() float64
+	ModifyDistance(ctx RuntimeContext, owner, from, to Player, distance int) int
+	ModifyAttackRange(ctx RuntimeContext, owner, player Player, attackRange int) int
+}
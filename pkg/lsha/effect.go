@@ -1,6 +1,99 @@
 package lsha
 
+type Visibility int
+
+const (
+	VisibilityPublic Visibility = iota
+	VisibilityOwner
+	VisibilityHidden
+	// VisibilityTeam is visible to the owner and the teammates of the owner.
+	VisibilityTeam
+)
+
+// VisibleTo reports whether viewer may see something owned by owner.
+func (v Visibility) VisibleTo(owner, viewer Player) bool {
+	switch v {
+	case VisibilityPublic:
+		return true
+	case VisibilityOwner:
+		return owner != nil && owner == viewer
+	case VisibilityTeam:
+		return owner != nil && (owner == viewer || Teammate(owner)(viewer))
+	}
+	return false
+}
+
+type DurationUnit int
+
+const (
+	DurationPermanent DurationUnit = iota
+	DurationTurns
+	DurationRounds
+)
+
+// Duration is how long an effect lasts, turns are counted when the owner's turns end
+// and rounds are counted when a new round starts.
+type Duration struct {
+	Unit  DurationUnit
+	Count int
+}
+
+func Permanent() Duration       { return Duration{} }
+func Turns(count int) Duration  { return Duration{Unit: DurationTurns, Count: count} }
+func Rounds(count int) Duration { return Duration{Unit: DurationRounds, Count: count} }
+
+// Effect describes a status effect, its triggers are registered for the player while the effect lasts.
 type Effect interface {
 	Name() string
 	Description() string
+	Visibility() Visibility
+	Triggers() []Trigger
+}
+
+// PlayerEffect is an effect put on a player.
+type PlayerEffect interface {
+	Effect() Effect
+	Owner() Player
+	Stacks() int
+	// Remaining is the left count of the duration unit, it is meaningless for permanent effects.
+	Remaining() Duration
+}
+
+type Effects interface {
+	Get(name string) PlayerEffect
+	Has(name string) bool
+	List() []PlayerEffect
+	// Visible lists the effects viewer may see.
+	Visible(viewer Player) []PlayerEffect
+}
+
+type EffectBuilder struct {
+	name        string
+	description string
+	visibility  Visibility
+	triggers    []Trigger
+}
+
+func NewEffect(name string) *EffectBuilder {
+	return &EffectBuilder{name: name}
+}
+
+func (b *EffectBuilder) Describe(description string) *EffectBuilder {
+	b.description = description
+	return b
+}
+
+func (b *EffectBuilder) Visible(visibility Visibility) *EffectBuilder {
+	b.visibility = visibility
+	return b
 }
+
+func (b *EffectBuilder) Trigger(triggers ...Trigger) *EffectBuilder {
+	b.triggers = append(b.triggers, triggers...)
+	return b
+}
+
+func (b *EffectBuilder) Name() string           { return b.name }
+func (b *EffectBuilder) Description() string    { return b.description }
+func (b *EffectBuilder) Visibility() Visibility { return b.visibility }
+func (b *EffectBuilder) Triggers() []Trigger    { return b.triggers }
@@ -0,0 +1,23 @@
+package lsha
+
+type EquipSlot string
+
+const (
+	EquipSlotWeapon         EquipSlot = "system:equip:weapon"
+	EquipSlotArmor          EquipSlot = "system:equip:armor"
+	EquipSlotDefensiveHorse EquipSlot = "system:equip:defensive_horse"
+	EquipSlotOffensiveHorse EquipSlot = "system:equip:offensive_horse"
+	EquipSlotTreasure       EquipSlot = "system:equip:treasure"
+)
+
+// Equipment describes the equipment card with the same name.
+// Weapons set the attack range and horses change the distance by one,
+// unless the equipment is a DistanceModifier itself.
+type Equipment interface {
+	Name() string
+	Slot() EquipSlot
+	// AttackRange is only used by weapons.
+	AttackRange() int
+	// Triggers are registered for the owner while the card stays in its slot.
+	Triggers() []Trigger
+}
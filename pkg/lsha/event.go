@@ -1,10 +1,39 @@
 package lsha
 
 const (
-	EventPlayerPrepared = "system:player_prepared"
-	EventGameStarted    = "system:game_start"
-	EventTurnStarted    = "system:turn_start"
-	EventPhaseStarted   = "system:phase_start"
+	EventPlayerPrepared       = "system:player_prepared"
+	EventGameStarted          = "system:game_start"
+	EventTurnStarted          = "system:turn_start"
+	EventPhaseStarted         = "system:phase_start"
+	EventCardsMoved           = "system:cards_moved"
+	EventCardResponded        = "system:card_responded"
+	EventCardUsing            = "system:card_using"
+	EventCardUsed             = "system:card_used"
+	EventDelayedTrick         = "system:delayed_trick"
+	EventJudgmentResult       = "system:judgment_result"
+	EventDamageCausing        = "system:damage_causing"
+	EventDamageInflicting     = "system:damage_inflicting"
+	EventDamageDone           = "system:damage_done"
+	EventHealing              = "system:healing"
+	EventHealed               = "system:healed"
+	EventDying                = "system:dying"
+	EventDeath                = "system:death"
+	EventChainChanged         = "system:chain_changed"
+	EventHandLimitCalculating = "system:hand_limit_calculating"
+	EventCardsDiscarded       = "system:cards_discarded"
+	EventDrawCountCalculating = "system:draw_count_calculating"
+	EventSkillUsed            = "system:skill_used"
+	EventHeroSelected         = "system:hero_selected"
+	EventEffectAdded          = "system:effect_added"
+	EventEffectRemoved        = "system:effect_removed"
+	EventMarkChanged          = "system:mark_changed"
+	EventSkillGained          = "system:skill_gained"
+	EventSkillLost            = "system:skill_lost"
+	EventSkillAwakened        = "system:skill_awakened"
+	EventSkillSpent           = "system:skill_spent"
+	EventHeroRevealed         = "system:hero_revealed"
+	EventHeroChanged          = "system:hero_changed"
+	EventGameOver             = "system:game_over"
 )
 
 type (
@@ -53,11 +82,408 @@ func (e *PhaseStartedEvent) SetTurn(turn Turn)    { e.turn = turn }
 func (e *PhaseStartedEvent) Phase() Phase         { return e.phase }
 func (e *PhaseStartedEvent) SetPhase(phase Phase) { e.phase = phase }
 
-func (e *GameStartedEvent) Name() string    { return EventGameStarted }
-func (e *PlayerPreparedEvent) Name() string { return EventPlayerPrepared }
-func (e *TurnStartedEvent) Name() string    { return EventTurnStarted }
-func (e *PhaseStartedEvent) Name() string   { return EventPhaseStarted }
+type CardsMovedEvent struct {
+	cards  []Card
+	from   Zone
+	to     Zone
+	reason string
+}
+
+func (e *CardsMovedEvent) Cards() []Card           { return e.cards }
+func (e *CardsMovedEvent) SetCards(cards []Card)   { e.cards = cards }
+func (e *CardsMovedEvent) From() Zone              { return e.from }
+func (e *CardsMovedEvent) SetFrom(from Zone)       { e.from = from }
+func (e *CardsMovedEvent) To() Zone                { return e.to }
+func (e *CardsMovedEvent) SetTo(to Zone)           { e.to = to }
+func (e *CardsMovedEvent) Reason() string          { return e.reason }
+func (e *CardsMovedEvent) SetReason(reason string) { e.reason = reason }
+
+// Visible reports whether viewer may see the moved cards, which is when either side of the move is visible.
+func (e *CardsMovedEvent) Visible(viewer Player) bool {
+	return ZoneVisibleTo(e.from, viewer) || ZoneVisibleTo(e.to, viewer)
+}
+
+// CountVisible reports whether viewer may see how many cards are moved, which is when the count of either side is visible.
+func (e *CardsMovedEvent) CountVisible(viewer Player) bool {
+	return ZoneCountVisibleTo(e.from, viewer) || ZoneCountVisibleTo(e.to, viewer)
+}
+
+type CardRespondedEvent struct {
+	player    Player
+	card      Card
+	respondTo Event
+}
+
+func (e *CardRespondedEvent) Player() Player               { return e.player }
+func (e *CardRespondedEvent) SetPlayer(player Player)      { e.player = player }
+func (e *CardRespondedEvent) Card() Card                   { return e.card }
+func (e *CardRespondedEvent) SetCard(card Card)            { e.card = card }
+func (e *CardRespondedEvent) RespondTo() Event             { return e.respondTo }
+func (e *CardRespondedEvent) SetRespondTo(respondTo Event) { e.respondTo = respondTo }
+
+type CardUsingEvent struct {
+	player    Player
+	card      Card
+	targets   []Player
+	cancelled bool
+}
+
+func (e *CardUsingEvent) Player() Player              { return e.player }
+func (e *CardUsingEvent) SetPlayer(player Player)     { e.player = player }
+func (e *CardUsingEvent) Card() Card                  { return e.card }
+func (e *CardUsingEvent) SetCard(card Card)           { e.card = card }
+func (e *CardUsingEvent) Targets() []Player           { return e.targets }
+func (e *CardUsingEvent) SetTargets(targets []Player) { e.targets = targets }
+func (e *CardUsingEvent) Cancelled() bool             { return e.cancelled }
+func (e *CardUsingEvent) Cancel()                     { e.cancelled = true }
+
+type CardUsedEvent struct {
+	player  Player
+	card    Card
+	targets []Player
+}
+
+func (e *CardUsedEvent) Player() Player              { return e.player }
+func (e *CardUsedEvent) SetPlayer(player Player)     { e.player = player }
+func (e *CardUsedEvent) Card() Card                  { return e.card }
+func (e *CardUsedEvent) SetCard(card Card)           { e.card = card }
+func (e *CardUsedEvent) Targets() []Player           { return e.targets }
+func (e *CardUsedEvent) SetTargets(targets []Player) { e.targets = targets }
+
+type DelayedTrickEvent struct {
+	player Player
+	card   Card
+}
+
+func (e *DelayedTrickEvent) Player() Player          { return e.player }
+func (e *DelayedTrickEvent) SetPlayer(player Player) { e.player = player }
+func (e *DelayedTrickEvent) Card() Card              { return e.card }
+func (e *DelayedTrickEvent) SetCard(card Card)       { e.card = card }
+
+type JudgmentResultEvent struct {
+	player Player
+	card   Card
+	reason string
+}
+
+func (e *JudgmentResultEvent) Player() Player          { return e.player }
+func (e *JudgmentResultEvent) SetPlayer(player Player) { e.player = player }
+func (e *JudgmentResultEvent) Card() Card              { return e.card }
+func (e *JudgmentResultEvent) SetCard(card Card)       { e.card = card }
+func (e *JudgmentResultEvent) Reason() string          { return e.reason }
+func (e *JudgmentResultEvent) SetReason(reason string) { e.reason = reason }
+
+type DamageCausingEvent struct {
+	*Damage
+}
+type DamageInflictingEvent struct {
+	*Damage
+}
+type DamageDoneEvent struct {
+	*Damage
+}
+type HealingEvent struct {
+	*Heal
+}
+type HealedEvent struct {
+	*Heal
+}
+
+type DyingEvent struct {
+	player Player
+	damage *Damage
+}
+
+func (e *DyingEvent) Player() Player           { return e.player }
+func (e *DyingEvent) SetPlayer(player Player)  { e.player = player }
+func (e *DyingEvent) Damage() *Damage          { return e.damage }
+func (e *DyingEvent) SetDamage(damage *Damage) { e.damage = damage }
+
+type DeathEvent struct {
+	player Player
+	damage *Damage
+}
+
+func (e *DeathEvent) Player() Player           { return e.player }
+func (e *DeathEvent) SetPlayer(player Player)  { e.player = player }
+func (e *DeathEvent) Damage() *Damage          { return e.damage }
+func (e *DeathEvent) SetDamage(damage *Damage) { e.damage = damage }
+
+// Killer is the source of the damage which caused the death, if any.
+func (e *DeathEvent) Killer() Player {
+	if e.damage == nil {
+		return nil
+	}
+	return e.damage.Source()
+}
+
+type ChainChangedEvent struct {
+	player  Player
+	chained bool
+}
+
+func (e *ChainChangedEvent) Player() Player          { return e.player }
+func (e *ChainChangedEvent) SetPlayer(player Player) { e.player = player }
+func (e *ChainChangedEvent) Chained() bool           { return e.chained }
+func (e *ChainChangedEvent) SetChained(chained bool) { e.chained = chained }
+
+type HandLimitCalculatingEvent struct {
+	player Player
+	limit  int
+}
+
+func (e *HandLimitCalculatingEvent) Player() Player          { return e.player }
+func (e *HandLimitCalculatingEvent) SetPlayer(player Player) { e.player = player }
+func (e *HandLimitCalculatingEvent) Limit() int              { return e.limit }
+func (e *HandLimitCalculatingEvent) SetLimit(limit int)      { e.limit = limit }
+
+type CardsDiscardedEvent struct {
+	player Player
+	cards  []Card
+}
+
+func (e *CardsDiscardedEvent) Player() Player          { return e.player }
+func (e *CardsDiscardedEvent) SetPlayer(player Player) { e.player = player }
+func (e *CardsDiscardedEvent) Cards() []Card           { return e.cards }
+func (e *CardsDiscardedEvent) SetCards(cards []Card)   { e.cards = cards }
+
+type DrawCountCalculatingEvent struct {
+	player   Player
+	count    int
+	replaced bool
+}
+
+func (e *DrawCountCalculatingEvent) Player() Player          { return e.player }
+func (e *DrawCountCalculatingEvent) SetPlayer(player Player) { e.player = player }
+func (e *DrawCountCalculatingEvent) Count() int              { return e.count }
+func (e *DrawCountCalculatingEvent) SetCount(count int)      { e.count = count }
+func (e *DrawCountCalculatingEvent) Replaced() bool          { return e.replaced }
+
+// Replace skips the default drawing, the trigger which calls it takes its own action instead.
+func (e *DrawCountCalculatingEvent) Replace() { e.replaced = true }
+
+type SkillUsedEvent struct {
+	player  Player
+	skill   string
+	cards   []Card
+	targets []Player
+}
+
+func (e *SkillUsedEvent) Player() Player              { return e.player }
+func (e *SkillUsedEvent) SetPlayer(player Player)     { e.player = player }
+func (e *SkillUsedEvent) Skill() string               { return e.skill }
+func (e *SkillUsedEvent) SetSkill(skill string)       { e.skill = skill }
+func (e *SkillUsedEvent) Cards() []Card               { return e.cards }
+func (e *SkillUsedEvent) SetCards(cards []Card)       { e.cards = cards }
+func (e *SkillUsedEvent) Targets() []Player           { return e.targets }
+func (e *SkillUsedEvent) SetTargets(targets []Player) { e.targets = targets }
+
+type HeroSelectedEvent struct {
+	player Player
+	hero   Hero
+}
+
+func (e *HeroSelectedEvent) Player() Player          { return e.player }
+func (e *HeroSelectedEvent) SetPlayer(player Player) { e.player = player }
+func (e *HeroSelectedEvent) Hero() Hero              { return e.hero }
+func (e *HeroSelectedEvent) SetHero(hero Hero)       { e.hero = hero }
+
+type EffectAddedEvent struct {
+	player Player
+	effect PlayerEffect
+	stacks int
+}
+
+func (e *EffectAddedEvent) Player() Player                { return e.player }
+func (e *EffectAddedEvent) SetPlayer(player Player)       { e.player = player }
+func (e *EffectAddedEvent) Effect() PlayerEffect          { return e.effect }
+func (e *EffectAddedEvent) SetEffect(effect PlayerEffect) { e.effect = effect }
+
+// Stacks is how many stacks were added.
+func (e *EffectAddedEvent) Stacks() int          { return e.stacks }
+func (e *EffectAddedEvent) SetStacks(stacks int) { e.stacks = stacks }
+
+type EffectRemovedEvent struct {
+	player  Player
+	effect  PlayerEffect
+	stacks  int
+	expired bool
+}
+
+func (e *EffectRemovedEvent) Player() Player                { return e.player }
+func (e *EffectRemovedEvent) SetPlayer(player Player)       { e.player = player }
+func (e *EffectRemovedEvent) Effect() PlayerEffect          { return e.effect }
+func (e *EffectRemovedEvent) SetEffect(effect PlayerEffect) { e.effect = effect }
+
+// Stacks is how many stacks were removed, the effect is gone when no stacks are left.
+func (e *EffectRemovedEvent) Stacks() int          { return e.stacks }
+func (e *EffectRemovedEvent) SetStacks(stacks int) { e.stacks = stacks }
+
+// Expired reports whether the effect was removed because its duration ran out.
+func (e *EffectRemovedEvent) Expired() bool           { return e.expired }
+func (e *EffectRemovedEvent) SetExpired(expired bool) { e.expired = expired }
+
+type MarkChangedEvent struct {
+	holder MarkHolder
+	mark   string
+	old    int
+	count  int
+}
+
+func (e *MarkChangedEvent) Holder() MarkHolder          { return e.holder }
+func (e *MarkChangedEvent) SetHolder(holder MarkHolder) { e.holder = holder }
+func (e *MarkChangedEvent) Mark() string                { return e.mark }
+func (e *MarkChangedEvent) SetMark(mark string)         { e.mark = mark }
+func (e *MarkChangedEvent) Old() int                    { return e.old }
+func (e *MarkChangedEvent) SetOld(old int)              { e.old = old }
+func (e *MarkChangedEvent) Count() int                  { return e.count }
+func (e *MarkChangedEvent) SetCount(count int)          { e.count = count }
+
+type SkillGainedEvent struct {
+	player Player
+	skill  SkillDef
+}
+
+func (e *SkillGainedEvent) Player() Player          { return e.player }
+func (e *SkillGainedEvent) SetPlayer(player Player) { e.player = player }
+func (e *SkillGainedEvent) Skill() SkillDef         { return e.skill }
+func (e *SkillGainedEvent) SetSkill(skill SkillDef) { e.skill = skill }
+
+type SkillLostEvent struct {
+	player Player
+	skill  SkillDef
+}
+
+func (e *SkillLostEvent) Player() Player          { return e.player }
+func (e *SkillLostEvent) SetPlayer(player Player) { e.player = player }
+func (e *SkillLostEvent) Skill() SkillDef         { return e.skill }
+func (e *SkillLostEvent) SetSkill(skill SkillDef) { e.skill = skill }
+
+type SkillAwakenedEvent struct {
+	player Player
+	skill  SkillDef
+}
+
+func (e *SkillAwakenedEvent) Player() Player          { return e.player }
+func (e *SkillAwakenedEvent) SetPlayer(player Player) { e.player = player }
+func (e *SkillAwakenedEvent) Skill() SkillDef         { return e.skill }
+func (e *SkillAwakenedEvent) SetSkill(skill SkillDef) { e.skill = skill }
+
+type SkillSpentEvent struct {
+	player Player
+	skill  SkillDef
+}
+
+func (e *SkillSpentEvent) Player() Player          { return e.player }
+func (e *SkillSpentEvent) SetPlayer(player Player) { e.player = player }
+func (e *SkillSpentEvent) Skill() SkillDef         { return e.skill }
+func (e *SkillSpentEvent) SetSkill(skill SkillDef) { e.skill = skill }
+
+type HeroRevealedEvent struct {
+	player Player
+	hero   Hero
+}
+
+func (e *HeroRevealedEvent) Player() Player          { return e.player }
+func (e *HeroRevealedEvent) SetPlayer(player Player) { e.player = player }
+func (e *HeroRevealedEvent) Hero() Hero              { return e.hero }
+func (e *HeroRevealedEvent) SetHero(hero Hero)       { e.hero = hero }
+
+type HeroChangedEvent struct {
+	player Player
+	old    HeroDef
+	hero   Hero
+}
+
+func (e *HeroChangedEvent) Player() Player          { return e.player }
+func (e *HeroChangedEvent) SetPlayer(player Player) { e.player = player }
+func (e *HeroChangedEvent) Old() HeroDef            { return e.old }
+func (e *HeroChangedEvent) SetOld(old HeroDef)      { e.old = old }
+func (e *HeroChangedEvent) Hero() Hero              { return e.hero }
+func (e *HeroChangedEvent) SetHero(hero Hero)       { e.hero = hero }
+
+type GameOverEvent struct {
+	winners []Player
+}
+
+func (e *GameOverEvent) Winners() []Player           { return e.winners }
+func (e *GameOverEvent) SetWinners(winners []Player) { e.winners = winners }
+
+func (e *GameStartedEvent) Name() string          { return EventGameStarted }
+func (e *PlayerPreparedEvent) Name() string       { return EventPlayerPrepared }
+func (e *TurnStartedEvent) Name() string          { return EventTurnStarted }
+func (e *PhaseStartedEvent) Name() string         { return EventPhaseStarted }
+func (e *CardsMovedEvent) Name() string           { return EventCardsMoved }
+func (e *CardRespondedEvent) Name() string        { return EventCardResponded }
+func (e *CardUsingEvent) Name() string            { return EventCardUsing }
+func (e *CardUsedEvent) Name() string             { return EventCardUsed }
+func (e *DelayedTrickEvent) Name() string         { return EventDelayedTrick }
+func (e *JudgmentResultEvent) Name() string       { return EventJudgmentResult }
+func (e *DamageCausingEvent) Name() string        { return EventDamageCausing }
+func (e *DamageInflictingEvent) Name() string     { return EventDamageInflicting }
+func (e *DamageDoneEvent) Name() string           { return EventDamageDone }
+func (e *HealingEvent) Name() string              { return EventHealing }
+func (e *HealedEvent) Name() string               { return EventHealed }
+func (e *DyingEvent) Name() string                { return EventDying }
+func (e *DeathEvent) Name() string                { return EventDeath }
+func (e *ChainChangedEvent) Name() string         { return EventChainChanged }
+func (e *HandLimitCalculatingEvent) Name() string { return EventHandLimitCalculating }
+func (e *CardsDiscardedEvent) Name() string       { return EventCardsDiscarded }
+func (e *DrawCountCalculatingEvent) Name() string { return EventDrawCountCalculating }
+func (e *SkillUsedEvent) Name() string            { return EventSkillUsed }
+func (e *HeroSelectedEvent) Name() string         { return EventHeroSelected }
+func (e *EffectAddedEvent) Name() string          { return EventEffectAdded }
+func (e *EffectRemovedEvent) Name() string        { return EventEffectRemoved }
+func (e *MarkChangedEvent) Name() string          { return EventMarkChanged }
+func (e *SkillGainedEvent) Name() string          { return EventSkillGained }
+func (e *SkillLostEvent) Name() string            { return EventSkillLost }
+func (e *SkillAwakenedEvent) Name() string        { return EventSkillAwakened }
+func (e *SkillSpentEvent) Name() string           { return EventSkillSpent }
+func (e *HeroRevealedEvent) Name() string         { return EventHeroRevealed }
+func (e *HeroChangedEvent) Name() string          { return EventHeroChanged }
+func (e *GameOverEvent) Name() string             { return EventGameOver }
 
 func (e *PlayerPreparedEvent) StartPlayer() Player { return e.player }
 func (e *TurnStartedEvent) StartPlayer() Player    { return e.turn.Player() }
 func (e *PhaseStartedEvent) StartPlayer() Player   { return e.turn.Player() }
+func (e *CardRespondedEvent) StartPlayer() Player  { return e.player }
+func (e *CardUsingEvent) StartPlayer() Player      { return e.player }
+func (e *CardUsedEvent) StartPlayer() Player       { return e.player }
+func (e *DelayedTrickEvent) StartPlayer() Player   { return e.player }
+func (e *JudgmentResultEvent) StartPlayer() Player { return e.player }
+func (e *DamageCausingEvent) StartPlayer() Player {
+	if e.source != nil {
+		return e.source
+	}
+	return e.target
+}
+func (e *DamageInflictingEvent) StartPlayer() Player     { return e.target }
+func (e *DamageDoneEvent) StartPlayer() Player           { return e.target }
+func (e *HealingEvent) StartPlayer() Player              { return e.target }
+func (e *HealedEvent) StartPlayer() Player               { return e.target }
+func (e *DyingEvent) StartPlayer() Player                { return e.player }
+func (e *DeathEvent) StartPlayer() Player                { return e.player }
+func (e *ChainChangedEvent) StartPlayer() Player         { return e.player }
+func (e *HandLimitCalculatingEvent) StartPlayer() Player { return e.player }
+func (e *CardsDiscardedEvent) StartPlayer() Player       { return e.player }
+func (e *DrawCountCalculatingEvent) StartPlayer() Player { return e.player }
+func (e *SkillUsedEvent) StartPlayer() Player            { return e.player }
+func (e *HeroSelectedEvent) StartPlayer() Player         { return e.player }
+func (e *EffectAddedEvent) StartPlayer() Player          { return e.player }
+func (e *EffectRemovedEvent) StartPlayer() Player        { return e.player }
+func (e *MarkChangedEvent) StartPlayer() Player          { return e.holder.MarkOwner() }
+func (e *SkillGainedEvent) StartPlayer() Player          { return e.player }
+func (e *SkillLostEvent) StartPlayer() Player            { return e.player }
+func (e *SkillAwakenedEvent) StartPlayer() Player        { return e.player }
+func (e *SkillSpentEvent) StartPlayer() Player           { return e.player }
+func (e *HeroRevealedEvent) StartPlayer() Player         { return e.player }
+func (e *HeroChangedEvent) StartPlayer() Player          { return e.player }
+func (e *CardsMovedEvent) StartPlayer() Player {
+	if p := e.to.Owner(); p != nil {
+		return p
+	}
+	if e.from != nil {
+		return e.from.Owner()
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package lsha
+
+import "iter"
+
+// PlayerFilter selects players, a nil filter matches every player.
+type PlayerFilter = func(player Player) bool
+
+func MatchAll(filters ...PlayerFilter) PlayerFilter {
+	return func(player Player) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(player) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func MatchAny(filters ...PlayerFilter) PlayerFilter {
+	return func(player Player) bool {
+		for _, filter := range filters {
+			if filter == nil || filter(player) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func Not(filter PlayerFilter) PlayerFilter {
+	return func(player Player) bool { return filter != nil && !filter(player) }
+}
+
+func Alive() PlayerFilter {
+	return func(player Player) bool { return player.IsAlive() }
+}
+
+func Other(self Player) PlayerFilter {
+	return func(player Player) bool { return player != self }
+}
+
+// Teammate matches the other players in the team of player.
+func Teammate(player Player) PlayerFilter {
+	return func(p Player) bool {
+		return p != nil && p != player && player.Team() >= 0 && p.Team() == player.Team()
+	}
+}
+
+func InAttackRange(ctx RuntimeContext, from Player) PlayerFilter {
+	return func(player Player) bool { return ctx.InAttackRange(from, player) }
+}
+
+func WithinDistance(ctx RuntimeContext, from Player, distance int) PlayerFilter {
+	return func(player Player) bool { return player != from && ctx.Distance(from, player) <= distance }
+}
+
+func OfGender(gender Gender) PlayerFilter {
+	return func(player Player) bool {
+		hero := player.Hero()
+		return hero != nil && hero.Def().Gender() == gender
+	}
+}
+
+func OfKingdom(kingdom Kingdom) PlayerFilter {
+	return func(player Player) bool { return player.Kingdom() == kingdom }
+}
+
+func HasCardsIn(zoneTypes ...ZoneType) PlayerFilter {
+	return func(player Player) bool {
+		for _, zoneType := range zoneTypes {
+			if zone := player.Zone(zoneType); zone != nil && zone.Len() > 0 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func Wounded() PlayerFilter {
+	return func(player Player) bool { return player.HP() < player.MaxHP() }
+}
+
+// FilterPlayers iterates the alive players matching filter in seat order from start.
+func FilterPlayers(ctx RuntimeContext, start Player, filter PlayerFilter) iter.Seq[Player] {
+	return func(yield func(Player) bool) {
+		ctx.PlayerIter(start)(func(p Player) bool {
+			if filter != nil && !filter(p) {
+				return true
+			}
+			return yield(p)
+		})
+	}
+}
+
+// Players collects the alive players matching filter in seat order from start.
+func Players(ctx RuntimeContext, start Player, filter PlayerFilter) []Player {
+	var players []Player
+	FilterPlayers(ctx, start, filter)(func(p Player) bool {
+		players = append(players, p)
+		return true
+	})
+	return players
+}
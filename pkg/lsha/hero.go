@@ -1,6 +1,78 @@
 package lsha
 
+type Kingdom string
+
+const (
+	KingdomWei Kingdom = "wei"
+	KingdomShu Kingdom = "shu"
+	KingdomWu  Kingdom = "wu"
+	KingdomQun Kingdom = "qun"
+	KingdomGod Kingdom = "god"
+)
+
+// Role is the identity of a player given by the mode, such as the lord in the identity mode.
+type Role string
+
+type Gender int
+
+const (
+	GenderNone Gender = iota
+	GenderMale
+	GenderFemale
+)
+
+type HeroSlot int
+
+const (
+	HeroPrimary HeroSlot = iota
+	HeroSecondary
+)
+
 type Hero interface {
+	Def() HeroDef
+	Player() Player
+	Slot() HeroSlot
+	// IsRevealed reports whether the hero is face up, the skills of a hidden hero are not gained.
+	IsRevealed() bool
 }
+
+// CombinedMaxHP is the default max hp of a player holding two heroes.
+func CombinedMaxHP(primary, secondary HeroDef) int {
+	return (primary.MaxHP() + secondary.MaxHP()) / 2
+}
+
 type HeroDef interface {
+	ID() string
+	Name() string
+	Kingdom() Kingdom
+	Gender() Gender
+	MaxHP() int
+	Skills() []string
+}
+
+func NewHeroDef(id, name string, kingdom Kingdom, gender Gender, maxHP int, skills ...string) HeroDef {
+	return &heroDef{
+		id:      id,
+		name:    name,
+		kingdom: kingdom,
+		gender:  gender,
+		maxHP:   maxHP,
+		skills:  skills,
+	}
 }
+
+type heroDef struct {
+	id      string
+	name    string
+	kingdom Kingdom
+	gender  Gender
+	maxHP   int
+	skills  []string
+}
+
+func (h *heroDef) ID() string       { return h.id }
+func (h *heroDef) Name() string     { return h.name }
+func (h *heroDef) Kingdom() Kingdom { return h.kingdom }
+func (h *heroDef) Gender() Gender   { return h.gender }
+func (h *heroDef) MaxHP() int       { return h.maxHP }
+func (h *heroDef) Skills() []string { return h.skills }
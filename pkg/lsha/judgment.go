@@ -0,0 +1,11 @@
+package lsha
+
+// DelayedTrick describes the delayed trick card with the same name.
+type DelayedTrick interface {
+	Name() string
+	// Effective reports whether the judgment card makes the trick take effect.
+	Effective(judgment Card) bool
+	// Resolve is called after the judgment, effective is false if the trick was nullified.
+	// The trick card is discarded afterward unless Resolve moved it, e.g. to the next player.
+	Resolve(ctx Context, player Player, trick Card, effective bool)
+}
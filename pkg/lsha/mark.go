@@ -0,0 +1,22 @@
+package lsha
+
+// Mark is a named counter put on a player or a card.
+type Mark interface {
+	Name() string
+	Count() int
+	Visibility() Visibility
+}
+
+type Marks interface {
+	Count(name string) int
+	List() []Mark
+	// Visible lists the marks viewer may see.
+	Visible(viewer Player) []Mark
+}
+
+// MarkHolder is a player or a card instance carrying marks.
+type MarkHolder interface {
+	Marks() Marks
+	// MarkOwner is the player the owner-visible marks are shown to, it is the holding player of a card.
+	MarkOwner() Player
+}
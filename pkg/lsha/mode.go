@@ -13,19 +13,37 @@ type ModeRepository interface {
 
 type ModeRegistration interface {
 	SetHeroDef(h HeroDef)
-	DeleteHeroDef(name string)
+	DeleteHeroDef(id string)
+	HeroDefs() []HeroDef
+	SetSkillDef(s SkillDef)
+	DeleteSkillDef(name string)
+	// SetCombinedMaxHP replaces CombinedMaxHP for the players holding two heroes.
+	SetCombinedMaxHP(f func(primary, secondary HeroDef) int)
 }
 type ModeInitUserBuilder interface {
 	User() User
 	Order() int
 	RewriteOrder(order int) ModeInitUserBuilder
 	BindData(data any) ModeInitUserBuilder
+	// Role sets the role of the player, lord-only skills are only gained by the lord.
+	Role(role Role, lord bool) ModeInitUserBuilder
+	// Team is the team picked in the room, -1 if none was picked.
+	Team() int
+	RewriteTeam(team int) ModeInitUserBuilder
+}
+
+// TeamMember is implemented by the users which picked a team in the room.
+type TeamMember interface {
+	User
+	Team() int
 }
 type ModeUserConfigBuilder interface {
 	MinPlayer(playerCount int) ModeUserConfigBuilder
 	MaxPlayer(playerCount int) ModeUserConfigBuilder
 	ValidUser(validator func(user User) (reason string)) ModeUserConfigBuilder
 	DisableRandomOrder() ModeUserConfigBuilder
+	// Teams lets the users pick one of count teams in the room before the game starts.
+	Teams(count int) ModeUserConfigBuilder
 }
 type ModeBuilder interface {
 	Name(name string) ModeBuilder
@@ -35,6 +53,16 @@ type ModeBuilder interface {
 	OnCreateConfig(f ModeRoomConfigBuilder) ModeBuilder
 	Init(f ModeInitializer) ModeBuilder
 	NextTurn(f TurnStarter) ModeBuilder
+	HeroSelection(builderInitializer func(builder ModeHeroSelectionBuilder)) ModeBuilder
+}
+
+// ModeHeroSelectionBuilder configures the hero selection before the game starts.
+type ModeHeroSelectionBuilder interface {
+	Candidates(count int) ModeHeroSelectionBuilder
+	// FirstPicker returns the player who picks and reveals the hero before the others pick simultaneously.
+	FirstPicker(f func(ctx Context) Player) ModeHeroSelectionBuilder
+	// Selector replaces the default selection when it returns true, e.g. a ban/pick draft.
+	Selector(f func(ctx Context) (handled bool)) ModeHeroSelectionBuilder
 }
 type ConfigBuilder interface {
 	DataHolder
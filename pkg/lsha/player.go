@@ -5,5 +5,29 @@ type Player interface {
 	Order() int
 	User() User
 	IsAlive() bool
-	Effects() Effect
+	HP() int
+	MaxHP() int
+	IsChained() bool
+	Effects() Effects
+	MarkHolder
+	Zone(zoneType ZoneType) Zone
+	// Pile returns nil if the player has no pile named name.
+	Pile(name string) Zone
+	Piles() []Zone
+	Equipment(slot EquipSlot) Card
+	// Hero is the primary hero.
+	Hero() Hero
+	// SecondaryHero returns nil unless the player holds two heroes.
+	SecondaryHero() Hero
+	// Kingdom is the kingdom of the hero unless it is changed by SetKingdom.
+	Kingdom() Kingdom
+	Role() Role
+	// RoleVisibleTo reports whether viewer may see the role, only the owner may see it unless it is revealed.
+	RoleVisibleTo(viewer Player) bool
+	// Team returns -1 if the mode has no teams.
+	Team() int
+	IsLord() bool
+	Skills() []SkillDef
+	HasSkill(name string) bool
+	SkillState(name string) SkillState
 }
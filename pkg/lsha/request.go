@@ -0,0 +1,221 @@
+package lsha
+
+import "time"
+
+const (
+	RequestRespondCard = "system:request:respond_card"
+	RequestUseCard     = "system:request:use_card"
+	RequestDiscard     = "system:request:discard"
+	RequestSelectHero  = "system:request:select_hero"
+
+	DefaultRequestTimeout = 15 * time.Second
+)
+
+type Request interface {
+	Name() string
+	Timeout() time.Duration
+	Accept(reply any) bool
+}
+
+// Responder is implemented by users that can answer requests, a nil reply means pass.
+type Responder interface {
+	Respond(player Player, request Request) (reply <-chan any)
+}
+
+// ConvertReply answers a CardRequest by converting sources with the named converter.
+type ConvertReply struct {
+	Converter string
+	Sources   []Card
+}
+
+type CardRequest struct {
+	name       string
+	prompt     string
+	cards      []Card
+	min        int
+	max        int
+	conversion map[string][]Card
+	timeout    time.Duration
+}
+
+func NewCardRequest(name string, cards []Card, min, max int) *CardRequest {
+	return &CardRequest{
+		name:    name,
+		cards:   cards,
+		min:     min,
+		max:     max,
+		timeout: DefaultRequestTimeout,
+	}
+}
+
+func (r *CardRequest) Name() string                   { return r.name }
+func (r *CardRequest) Prompt() string                 { return r.prompt }
+func (r *CardRequest) SetPrompt(prompt string)        { r.prompt = prompt }
+func (r *CardRequest) Cards() []Card                  { return r.cards }
+func (r *CardRequest) Min() int                       { return r.min }
+func (r *CardRequest) Max() int                       { return r.max }
+func (r *CardRequest) Conversions() map[string][]Card { return r.conversion }
+func (r *CardRequest) AddConversion(converter string, candidates []Card) {
+	if r.conversion == nil {
+		r.conversion = map[string][]Card{}
+	}
+	r.conversion[converter] = candidates
+}
+func (r *CardRequest) Timeout() time.Duration           { return r.timeout }
+func (r *CardRequest) SetTimeout(timeout time.Duration) { r.timeout = timeout }
+
+func (r *CardRequest) Accept(reply any) bool {
+	switch reply := reply.(type) {
+	case []Card:
+		return len(reply) >= r.min && len(reply) <= r.max && allContained(r.cards, reply)
+	case *ConvertReply:
+		candidates, ok := r.conversion[reply.Converter]
+		return ok && len(reply.Sources) > 0 && allContained(candidates, reply.Sources)
+	}
+	return false
+}
+
+func allContained(candidates, cards []Card) bool {
+	chosen := make(map[uint64]struct{}, len(cards))
+	for _, card := range cards {
+		if _, ok := chosen[card.ID()]; ok || !containsCard(candidates, card) {
+			return false
+		}
+		chosen[card.ID()] = struct{}{}
+	}
+	return true
+}
+
+func containsCard(cards []Card, card Card) bool {
+	for _, c := range cards {
+		if c.ID() == card.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// UseCardReply answers a UseCardRequest with either a hand card, a conversion,
+// or an active skill with the cards and targets chosen for it.
+type UseCardReply struct {
+	Cards   []Card
+	Convert *ConvertReply
+	Skill   string
+	Targets []Player
+}
+
+// PlayChoice is the resolved UseCardReply, Card is the converted card if a conversion was chosen.
+type PlayChoice struct {
+	Card    Card
+	Skill   string
+	Cards   []Card
+	Targets []Player
+}
+
+type UseCardRequest struct {
+	*CardRequest
+	skills     []string
+	skillCards []Card
+	targets    []Player
+}
+
+func NewUseCardRequest(request *CardRequest) *UseCardRequest {
+	return &UseCardRequest{CardRequest: request}
+}
+
+func (r *UseCardRequest) Skills() []string { return r.skills }
+func (r *UseCardRequest) AddSkill(skill string) {
+	r.skills = append(r.skills, skill)
+}
+
+// SkillCards are the cards which may be chosen for the skills.
+func (r *UseCardRequest) SkillCards() []Card { return r.skillCards }
+func (r *UseCardRequest) SetSkillCards(cards []Card) {
+	r.skillCards = cards
+}
+
+// Targets are the players which may be chosen as targets, nil means any player.
+func (r *UseCardRequest) Targets() []Player { return r.targets }
+func (r *UseCardRequest) SetTargets(targets []Player) {
+	if targets == nil {
+		targets = []Player{}
+	}
+	r.targets = targets
+}
+
+func (r *UseCardRequest) targetable(target Player) bool {
+	if r.targets == nil {
+		return true
+	}
+	for _, t := range r.targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *UseCardRequest) Accept(reply any) bool {
+	use, ok := reply.(*UseCardReply)
+	if !ok {
+		return false
+	}
+	chosen := make(map[Player]struct{}, len(use.Targets))
+	for _, target := range use.Targets {
+		if _, ok := chosen[target]; ok || target == nil {
+			return false
+		}
+		if !r.targetable(target) {
+			return false
+		}
+		chosen[target] = struct{}{}
+	}
+	if use.Skill != "" {
+		for _, skill := range r.skills {
+			if skill == use.Skill {
+				return allContained(r.skillCards, use.Cards)
+			}
+		}
+		return false
+	}
+	if use.Convert != nil {
+		return r.CardRequest.Accept(use.Convert)
+	}
+	return r.CardRequest.Accept(use.Cards)
+}
+
+// OptionRequest asks to choose one of the options, the reply is the chosen option.
+type OptionRequest struct {
+	name    string
+	prompt  string
+	options []string
+	timeout time.Duration
+}
+
+func NewOptionRequest(name string, options []string) *OptionRequest {
+	return &OptionRequest{
+		name:    name,
+		options: options,
+		timeout: DefaultRequestTimeout,
+	}
+}
+
+func (r *OptionRequest) Name() string                     { return r.name }
+func (r *OptionRequest) Prompt() string                   { return r.prompt }
+func (r *OptionRequest) SetPrompt(prompt string)          { r.prompt = prompt }
+func (r *OptionRequest) Options() []string                { return r.options }
+func (r *OptionRequest) Timeout() time.Duration           { return r.timeout }
+func (r *OptionRequest) SetTimeout(timeout time.Duration) { r.timeout = timeout }
+
+func (r *OptionRequest) Accept(reply any) bool {
+	option, ok := reply.(string)
+	if !ok {
+		return false
+	}
+	for _, o := range r.options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package lsha
+
+type SkillTag int
+
+const (
+	SkillTagLocked SkillTag = 1 << iota
+	SkillTagLimited
+	SkillTagAwakening
+	SkillTagLordOnly
+)
+
+func (t SkillTag) Has(tag SkillTag) bool {
+	return t&tag == tag
+}
+
+// SkillState is kept for the whole game even if the skill is lost and gained again.
+type SkillState int
+
+const (
+	SkillReady SkillState = iota
+	// SkillSpent is a limited skill which has been used.
+	SkillSpent
+	SkillAwakened
+)
+
+// SkillDef describes a skill, its triggers, converters and modifiers are registered for the player owning it.
+type SkillDef interface {
+	Name() string
+	Description() string
+	Tags() SkillTag
+	Triggers() []Trigger
+	Converters() []Converter
+	Modifiers() []DistanceModifier
+	// Active returns nil if the skill can not be used actively in the play phase.
+	Active() ActiveSkill
+}
+
+type ActiveSkill interface {
+	Usable(ctx Context, player Player) bool
+	// Use returns false if the chosen cards or targets are not acceptable.
+	Use(ctx Context, player Player, cards []Card, targets []Player) (used bool)
+}
+
+type SkillBuilder struct {
+	name        string
+	description string
+	tags        SkillTag
+	triggers    []Trigger
+	converters  []Converter
+	modifiers   []DistanceModifier
+	active      ActiveSkill
+}
+
+func NewSkillDef(name string) *SkillBuilder {
+	return &SkillBuilder{name: name}
+}
+
+func (b *SkillBuilder) Describe(description string) *SkillBuilder {
+	b.description = description
+	return b
+}
+
+func (b *SkillBuilder) Tag(tags SkillTag) *SkillBuilder {
+	b.tags |= tags
+	return b
+}
+
+func (b *SkillBuilder) Trigger(triggers ...Trigger) *SkillBuilder {
+	b.triggers = append(b.triggers, triggers...)
+	return b
+}
+
+func (b *SkillBuilder) Converter(converters ...Converter) *SkillBuilder {
+	b.converters = append(b.converters, converters...)
+	return b
+}
+
+func (b *SkillBuilder) Modifier(modifiers ...DistanceModifier) *SkillBuilder {
+	b.modifiers = append(b.modifiers, modifiers...)
+	return b
+}
+
+func (b *SkillBuilder) Activate(active ActiveSkill) *SkillBuilder {
+	b.active = active
+	return b
+}
+
+func (b *SkillBuilder) Name() string                  { return b.name }
+func (b *SkillBuilder) Description() string           { return b.description }
+func (b *SkillBuilder) Tags() SkillTag                { return b.tags }
+func (b *SkillBuilder) Triggers() []Trigger           { return b.triggers }
+func (b *SkillBuilder) Converters() []Converter       { return b.converters }
+func (b *SkillBuilder) Modifiers() []DistanceModifier { return b.modifiers }
+func (b *SkillBuilder) Active() ActiveSkill           { return b.active }
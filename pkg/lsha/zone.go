@@ -0,0 +1,54 @@
+package lsha
+
+type ZoneType string
+
+const (
+	ZoneDrawPile    ZoneType = "system:zone:draw_pile"
+	ZoneDiscardPile ZoneType = "system:zone:discard_pile"
+	ZoneProcessing  ZoneType = "system:zone:processing"
+	ZoneHand        ZoneType = "system:zone:hand"
+	ZoneEquip       ZoneType = "system:zone:equip"
+	ZoneJudge       ZoneType = "system:zone:judge"
+	// ZonePile is a named pile on a player, such as the cards put on the hero.
+	ZonePile ZoneType = "system:zone:pile"
+)
+
+type Zone interface {
+	Type() ZoneType
+	// Owner returns nil for public zones such as the draw pile.
+	Owner() Player
+	// Name is the name of a pile, it is empty for other zones.
+	Name() string
+	// Visibility tells who may see the cards in the zone.
+	Visibility() Visibility
+	// CountVisibility tells who may see how many cards are in the zone.
+	CountVisibility() Visibility
+	// Cards lists the cards placed as a virtual card, such as a converted delayed trick, as the virtual card.
+	Cards() []Card
+	// Len counts the physical cards.
+	Len() int
+}
+
+// ZoneVisibleTo reports whether viewer may see the cards in zone.
+func ZoneVisibleTo(zone Zone, viewer Player) bool {
+	return zone != nil && zone.Visibility().VisibleTo(zone.Owner(), viewer)
+}
+
+// ZoneCountVisibleTo reports whether viewer may see how many cards are in zone, which is always the case
+// when the cards are visible.
+func ZoneCountVisibleTo(zone Zone, viewer Player) bool {
+	return zone != nil && (zone.CountVisibility().VisibleTo(zone.Owner(), viewer) || ZoneVisibleTo(zone, viewer))
+}
+
+const (
+	MoveReasonDraw    = "system:move:draw"
+	MoveReasonShuffle = "system:move:shuffle"
+	MoveReasonRespond = "system:move:respond"
+	MoveReasonUse     = "system:move:use"
+	MoveReasonEquip   = "system:move:equip"
+	MoveReasonReplace = "system:move:replace"
+	MoveReasonJudge   = "system:move:judge"
+	MoveReasonDelay   = "system:move:delay"
+	MoveReasonDeath   = "system:move:death"
+	MoveReasonDiscard = "system:move:discard"
+)
@@ -0,0 +1,72 @@
+package basic
+
+import "github.com/ohanan/LambdaSha/pkg/lsha"
+
+func initCards(ctx lsha.Context) {
+	ctx.SetDelayedTrick(&delayedTrick{
+		name:      CardIndulgence,
+		effective: func(judgment lsha.Card) bool { return judgment.Suit() != lsha.SuitHeart },
+		resolve: func(ctx lsha.Context, player lsha.Player, trick lsha.Card) {
+			if turn := CurrentTurn(ctx); turn != nil {
+				turn.SkipPhase(PhasePlay)
+			}
+		},
+	})
+	ctx.SetDelayedTrick(&delayedTrick{
+		name:      CardSupplyShortage,
+		effective: func(judgment lsha.Card) bool { return judgment.Suit() != lsha.SuitClub },
+		resolve: func(ctx lsha.Context, player lsha.Player, trick lsha.Card) {
+			if turn := CurrentTurn(ctx); turn != nil {
+				turn.SkipPhase(PhaseHarvest)
+			}
+		},
+	})
+	ctx.SetDelayedTrick(&delayedTrick{
+		name: CardLightning,
+		effective: func(judgment lsha.Card) bool {
+			return judgment.Suit() == lsha.SuitSpade && judgment.Number() >= 2 && judgment.Number() <= 9
+		},
+		resolve: func(ctx lsha.Context, player lsha.Player, trick lsha.Card) {
+			ctx.Damage(lsha.NewDamage(nil, player, 3, lsha.DamageThunder, trick))
+		},
+		miss: func(ctx lsha.Context, player lsha.Player, trick lsha.Card) {
+			ctx.PlayerIter(ctx.NextPlayer(player))(func(next lsha.Player) bool {
+				return next != player && !ctx.PlaceDelayedTrick(next, trick)
+			})
+		},
+	})
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:rescue",
+		eventName: lsha.EventDying,
+		invoke: func(ctx lsha.Context) {
+			event := ctx.Event().(*lsha.DyingEvent)
+			dying := event.Player()
+			for dying.IsAlive() && dying.HP() <= 0 {
+				rescuer, peach := ctx.RespondCardInOrder(nil, event, lsha.CardNamed(CardPeach), nil)
+				if peach == nil {
+					return
+				}
+				ctx.Heal(lsha.NewHeal(rescuer, dying, 1, peach))
+			}
+		},
+	}, nil, lsha.EventDying)
+}
+
+type delayedTrick struct {
+	name      string
+	effective func(judgment lsha.Card) bool
+	resolve   func(ctx lsha.Context, player lsha.Player, trick lsha.Card)
+	miss      func(ctx lsha.Context, player lsha.Player, trick lsha.Card)
+}
+
+func (d *delayedTrick) Name() string { return d.name }
+func (d *delayedTrick) Effective(judgment lsha.Card) bool {
+	return judgment != nil && d.effective(judgment)
+}
+func (d *delayedTrick) Resolve(ctx lsha.Context, player lsha.Player, trick lsha.Card, effective bool) {
+	if effective {
+		d.resolve(ctx, player, trick)
+	} else if d.miss != nil {
+		d.miss(ctx, player, trick)
+	}
+}
@@ -0,0 +1,84 @@
+package basic
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/core"
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type testUser struct {
+	id    string
+	reply func(player lsha.Player, request lsha.Request) any
+}
+
+func (u *testUser) ID() string { return u.id }
+
+func (u *testUser) Respond(player lsha.Player, request lsha.Request) <-chan any {
+	ch := make(chan any, 1)
+	if u.reply != nil {
+		ch <- u.reply(player, request)
+	} else {
+		ch <- nil
+	}
+	return ch
+}
+
+// runGame runs the mode built by build for at most turns turns of the basic phases and returns the game,
+// setup is called right before the first turn.
+func runGame(build func(lsha.ModeBuilder), config any, turns int, setup func(ctx lsha.Context), users ...lsha.User) lsha.Context {
+	var game lsha.Context
+	var played int
+	next := NextTurn(func() BasicTurn { return &Turn{} })
+	core.BuildMode(func(mb lsha.ModeBuilder) {
+		build(mb)
+		mb.NextTurn(func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+			if game == nil {
+				game = ctx
+				if setup != nil {
+					setup(ctx)
+				}
+			}
+			if played >= turns {
+				return nil
+			}
+			played++
+			return next(ctx, tb)
+		})
+	}).Run(config, users)
+	return game
+}
+
+func playerOf(ctx lsha.Context, id string) (player lsha.Player) {
+	ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+		if p.User().ID() == id {
+			player = p
+		}
+		return player == nil
+	})
+	return
+}
+
+func TestLightning(t *testing.T) {
+	ctx := runGame(initOneOnOne, nil, 0, nil, &testUser{id: "a"}, &testUser{id: "b"})
+	a, b := playerOf(ctx, "a"), playerOf(ctx, "b")
+	for _, player := range []lsha.Player{a, b} {
+		ctx.SetMaxHP(player, 4)
+		ctx.SetHP(player, 4)
+	}
+	lightning := ctx.NewCard(CardLightning, lsha.CardTypeDelayedTrick, lsha.SuitSpade, 1)
+	ctx.NewCard(CardPeach, lsha.CardTypeBasic, lsha.SuitHeart, 5)
+	ctx.NewCard(CardSlash, lsha.CardTypeBasic, lsha.SuitSpade, 5)
+	if !ctx.PlaceDelayedTrick(a, lightning) {
+		t.Fatal("lightning should be placed")
+	}
+
+	ctx.ResolveDelayedTricks(a, lsha.CardNamed(CardNullification))
+	if a.HP() != 4 || a.Zone(lsha.ZoneJudge).Len() != 0 || b.Zone(lsha.ZoneJudge).Len() != 1 {
+		t.Fatal("missed lightning should be passed to the next player")
+	}
+	ctx.ResolveDelayedTricks(b, lsha.CardNamed(CardNullification))
+	if b.HP() != 1 || b.Zone(lsha.ZoneJudge).Len() != 0 {
+		t.Fatalf("hp of b = %d, lightning should deal 3 damage", b.HP())
+	}
+}
@@ -7,10 +7,13 @@ type Mode struct {
 type Player struct {
 }
 type Turn struct {
+	skipped   map[string]struct{}
+	slashUsed int
 }
 type Phase interface {
 	NextPhase() Phase
 	Name() string
+	Run(ctx lsha.Context)
 }
 
 type StartPhase struct {
@@ -42,15 +45,129 @@ func (s *PlayPhase) Name() string      { return PhasePlay }
 func (s *PostCheckPhase) Name() string { return PhasePostCheck }
 func (s *EndPhase) Name() string       { return PhaseEnd }
 
-func nextTurn(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+func (s *StartPhase) Run(ctx lsha.Context) {}
+func (s *PreCheckPhase) Run(ctx lsha.Context) {
+	ctx.ResolveDelayedTricks(ctx.Turn().Player(), lsha.CardNamed(CardNullification))
+}
+func (s *HarvestPhase) Run(ctx lsha.Context) {
+	player := ctx.Turn().Player()
+	event := &lsha.DrawCountCalculatingEvent{}
+	event.SetPlayer(player)
+	event.SetCount(DefaultDrawCount)
+	ctx.Invoke(event)
+	if !event.Replaced() {
+		ctx.DrawCards(player, event.Count())
+	}
+}
+func (s *PostCheckPhase) Run(ctx lsha.Context) {
+	player := ctx.Turn().Player()
+	if excess := player.Zone(lsha.ZoneHand).Len() - ctx.HandLimit(player); excess > 0 {
+		ctx.AskDiscard(player, excess, nil, true)
+	}
+}
+func (s *EndPhase) Run(ctx lsha.Context) {}
+
+func (t *Turn) SkipPhase(name string) {
+	if t.skipped == nil {
+		t.skipped = map[string]struct{}{}
+	}
+	t.skipped[name] = struct{}{}
+}
+
+func (t *Turn) PhaseSkipped(name string) bool {
+	_, ok := t.skipped[name]
+	return ok
+}
+
+func (t *Turn) basicTurn() *Turn { return t }
+
+// BasicTurn is the turn data of a basic mode, which embeds Turn.
+type BasicTurn interface {
+	basicTurn() *Turn
+}
+
+// CurrentTurn returns the basic turn data embedded in the turn data of any basic mode.
+func CurrentTurn(ctx lsha.Context) *Turn {
+	if t := lsha.TurnData[BasicTurn](ctx); t != nil {
+		return t.basicTurn()
+	}
+	return nil
+}
+
+// InitRules registers the basic phases, cards and play rules, and deals the initial hand cards.
+func InitRules(ctx lsha.Context) {
+	initDeal(ctx)
+	initPhases(ctx)
+	initCards(ctx)
+	initPlay(ctx)
+}
+
+// NextTurn gives the turn to the next player and runs the basic phases, newTurn creates the turn data.
+func NextTurn(newTurn func() BasicTurn) func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+	return func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+		return nextTurn(ctx, tb, newTurn())
+	}
+}
+
+func nextTurn(ctx lsha.Context, tb lsha.TurnBuilder, turnData BasicTurn) any {
+	turn := turnData.basicTurn()
 	tb.Player(ctx.NextPlayer(nil)).OnNextPhase(func(ctx lsha.Context, pb lsha.PhaseBuilder) (phaseData any) {
 		phase := lsha.PhaseData[Phase](ctx)
 		if phase == nil {
 			phase = &StartPhase{}
+		} else {
+			phase = phase.NextPhase()
+		}
+		for phase != nil && turn.PhaseSkipped(phase.Name()) {
+			phase = phase.NextPhase()
+		}
+		if phase == nil {
+			return nil
 		}
 		pb.Name(phase.Name())
 		return phase
 	})
-	turn := &oneOnOneTurn{}
-	return turn
+	return turnData
+}
+
+// initDeal deals InitialHandCards to every player in seat order when the game starts.
+func initDeal(ctx lsha.Context) {
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:deal",
+		eventName: lsha.EventGameStarted,
+		invoke: func(ctx lsha.Context) {
+			ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+				ctx.DrawCards(p, InitialHandCards)
+				return true
+			})
+		},
+	}, nil, lsha.EventGameStarted)
+}
+
+func initPhases(ctx lsha.Context) {
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:run_phase",
+		eventName: lsha.EventPhaseStarted,
+		invoke: func(ctx lsha.Context) {
+			if phase, ok := ctx.Event().(*lsha.PhaseStartedEvent).Phase().Data().(Phase); ok {
+				phase.Run(ctx)
+			}
+		},
+	}, nil, lsha.EventPhaseStarted)
+}
+
+type trigger struct {
+	name      string
+	eventName string
+	priority  float64
+	invoke    func(ctx lsha.Context)
+}
+
+func (t *trigger) Name() string      { return t.name }
+func (t *trigger) EventName() string { return t.eventName }
+func (t *trigger) Priority() float64 { return t.priority }
+func (t *trigger) Invoke(ctx lsha.Context, enter bool, result lsha.InvokeResult) {
+	if enter {
+		t.invoke(ctx)
+	}
 }
@@ -0,0 +1,91 @@
+package basic
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// plainMode plays the basic rules only, the players sit in the order of the users.
+func plainMode(mb lsha.ModeBuilder) {
+	mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+		builder.DisableRandomOrder()
+	}).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+		InitRules(ctx)
+		return nil
+	})
+}
+
+func TestTurnPhases(t *testing.T) {
+	tests := []struct {
+		name       string
+		hp         int
+		hand       int
+		drawDelta  int
+		replaced   bool
+		limitDelta int
+		wantHand   int
+	}{
+		{name: "draw", hp: 4, wantHand: DefaultDrawCount},
+		{name: "draw more", hp: 4, drawDelta: 1, wantHand: DefaultDrawCount + 1},
+		{name: "draw replaced", hp: 4, replaced: true},
+		{name: "discard to hp", hp: 2, hand: 3, wantHand: 2},
+		{name: "discard to raised limit", hp: 2, hand: 3, limitDelta: 1, wantHand: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a lsha.Player
+			ctx := runGame(plainMode, nil, 1, func(ctx lsha.Context) {
+				a = playerOf(ctx, "a")
+				ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+					ctx.SetMaxHP(p, 4)
+					ctx.SetHP(p, tt.hp)
+					return true
+				})
+				for i := 0; i < 10; i++ {
+					ctx.NewCard(CardSlash, lsha.CardTypeBasic, lsha.SuitSpade, i+1)
+				}
+				ctx.DrawCards(a, tt.hand)
+				ctx.AddTrigger(&trigger{name: "draw", invoke: func(ctx lsha.Context) {
+					event := ctx.Event().(*lsha.DrawCountCalculatingEvent)
+					event.SetCount(event.Count() + tt.drawDelta)
+					if tt.replaced {
+						event.Replace()
+					}
+				}}, nil, lsha.EventDrawCountCalculating)
+				ctx.AddTrigger(&trigger{name: "limit", invoke: func(ctx lsha.Context) {
+					event := ctx.Event().(*lsha.HandLimitCalculatingEvent)
+					event.SetLimit(event.Limit() + tt.limitDelta)
+				}}, nil, lsha.EventHandLimitCalculating)
+			}, &testUser{id: "a"}, &testUser{id: "b"})
+			if got := a.Zone(lsha.ZoneHand).Len(); got != tt.wantHand {
+				t.Fatalf("hand cards = %d, want %d", got, tt.wantHand)
+			}
+			drawn := 0
+			if !tt.replaced {
+				drawn = DefaultDrawCount + tt.drawDelta
+			}
+			if got, want := ctx.Zone(lsha.ZoneDiscardPile).Len(), tt.hand+drawn-tt.wantHand; got != want {
+				t.Fatalf("discarded = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestInitialDeal(t *testing.T) {
+	ctx := runGame(func(mb lsha.ModeBuilder) {
+		mb.Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+			for i := 0; i < 10; i++ {
+				ctx.NewCard(CardSlash, lsha.CardTypeBasic, lsha.SuitSpade, i+1)
+			}
+			InitRules(ctx)
+			return nil
+		})
+	}, nil, 0, nil, &testUser{id: "a"}, &testUser{id: "b"})
+	ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+		if got := p.Zone(lsha.ZoneHand).Len(); got != InitialHandCards {
+			t.Fatalf("hand cards of %s = %d, want %d", p.User().ID(), got, InitialHandCards)
+		}
+		return true
+	})
+}
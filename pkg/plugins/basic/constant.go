@@ -1,9 +1,14 @@
 package basic
 
 const (
-	PluginName   = "标准包"
-	ModeOneOnOne = "单挑"
-	Version      = 1
+	PluginName       = "标准包"
+	ModeOneOnOne     = "单挑"
+	ModeTwoVsTwo     = "2v2"
+	ModeThreeVsThree = "3v3"
+	Version          = 1
+
+	DefaultDrawCount = 2
+	InitialHandCards = 4
 )
 const (
 	PhaseStart     = "basic:phase:start"
@@ -13,3 +18,12 @@ const (
 	PhasePostCheck = "basic:phase:post-check"
 	PhaseEnd       = "basic:phase:end"
 )
+const (
+	CardNullification  = "无懈可击"
+	CardIndulgence     = "乐不思蜀"
+	CardSupplyShortage = "兵粮寸断"
+	CardLightning      = "闪电"
+	CardPeach          = "桃"
+	CardSlash          = "杀"
+	CardDodge          = "闪"
+)
@@ -0,0 +1,106 @@
+package basic
+
+import (
+	"strings"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+const (
+	EventHeroDrafted = "basic:hero_drafted"
+
+	RequestBanHero  = "basic:request:ban_hero"
+	RequestPickHero = "basic:request:pick_hero"
+
+	// DraftOff deals random heroes instead of drafting them.
+	DraftOff = "随机选将"
+)
+
+// DraftSequences are the ban/pick sequences offered in the room config, B bans and P picks a hero,
+// each group separated by '-' is taken by the next player in turn. The first one is the default.
+var DraftSequences = []string{"P-PP-PP-P", "B-B-P-PP-P", "B-B-P-PP-PP-P"}
+
+// HeroDraftedEvent is invoked after a hero is banned or picked, every player sees the result.
+type HeroDraftedEvent struct {
+	player lsha.Player
+	hero   lsha.HeroDef
+	banned bool
+}
+
+func (e *HeroDraftedEvent) Name() string             { return EventHeroDrafted }
+func (e *HeroDraftedEvent) StartPlayer() lsha.Player { return e.player }
+func (e *HeroDraftedEvent) Player() lsha.Player      { return e.player }
+func (e *HeroDraftedEvent) Hero() lsha.HeroDef       { return e.hero }
+func (e *HeroDraftedEvent) Banned() bool             { return e.banned }
+
+// Draft is the result of a ban/pick draft.
+type Draft struct {
+	banned []lsha.HeroDef
+	picked map[lsha.Player][]lsha.HeroDef
+}
+
+func (d *Draft) Banned() []lsha.HeroDef                   { return d.banned }
+func (d *Draft) Picked(player lsha.Player) []lsha.HeroDef { return d.picked[player] }
+
+// RunDraft lets players ban and pick heroes from the shared pool according to sequence,
+// a random hero is taken when a player does not answer.
+func RunDraft(ctx lsha.Context, sequence string, players []lsha.Player, pool []lsha.HeroDef) *Draft {
+	draft := &Draft{picked: map[lsha.Player][]lsha.HeroDef{}}
+	if len(players) == 0 {
+		return draft
+	}
+	pool = append([]lsha.HeroDef(nil), pool...)
+	for i, group := range strings.Split(sequence, "-") {
+		player := players[i%len(players)]
+		for _, action := range strings.ToUpper(group) {
+			if len(pool) == 0 {
+				return draft
+			}
+			ban := action == 'B'
+			name := RequestPickHero
+			if ban {
+				name = RequestBanHero
+			}
+			idx := askDraft(ctx, player, name, pool)
+			hero := pool[idx]
+			pool = append(pool[:idx], pool[idx+1:]...)
+			if ban {
+				draft.banned = append(draft.banned, hero)
+			} else {
+				draft.picked[player] = append(draft.picked[player], hero)
+			}
+			ctx.Invoke(&HeroDraftedEvent{player: player, hero: hero, banned: ban})
+		}
+	}
+	return draft
+}
+
+// DealHeroes gives each player count heroes from the top of the pool without banning or picking.
+func DealHeroes(players []lsha.Player, pool []lsha.HeroDef, count int) *Draft {
+	draft := &Draft{picked: map[lsha.Player][]lsha.HeroDef{}}
+	for _, player := range players {
+		n := min(count, len(pool))
+		draft.picked[player] = append([]lsha.HeroDef(nil), pool[:n]...)
+		pool = pool[n:]
+	}
+	return draft
+}
+
+// draftSize is how many heroes are banned or picked by sequence.
+func draftSize(sequence string) int {
+	return len(strings.ReplaceAll(sequence, "-", ""))
+}
+
+func askDraft(ctx lsha.Context, player lsha.Player, name string, pool []lsha.HeroDef) int {
+	ids := make([]string, len(pool))
+	for i, def := range pool {
+		ids[i] = def.ID()
+	}
+	reply := ctx.Ask(player, lsha.NewOptionRequest(name, ids))
+	for i, id := range ids {
+		if id == reply {
+			return i
+		}
+	}
+	return ctx.Rand().Intn(len(pool))
+}
@@ -0,0 +1,46 @@
+package basic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestDraftBans(t *testing.T) {
+	// each player takes the first hero left in the pool
+	first := func(player lsha.Player, request lsha.Request) any {
+		if r, ok := request.(*lsha.OptionRequest); ok {
+			return r.Options()[0]
+		}
+		return nil
+	}
+	ctx := runGame(oneOnOneMode, &oneOnOneConfig{draft: DraftOff}, 0, nil, &testUser{id: "a", reply: first}, &testUser{id: "b", reply: first})
+	a, b := playerOf(ctx, "a"), playerOf(ctx, "b")
+	var drafted []string
+	ctx.AddTrigger(&trigger{name: "drafted", invoke: func(ctx lsha.Context) {
+		event := ctx.Event().(*HeroDraftedEvent)
+		drafted = append(drafted, fmt.Sprintf("%s:%s:%v", event.Player().User().ID(), event.Hero().ID(), event.Banned()))
+	}}, nil, EventHeroDrafted)
+	ids := func(defs []lsha.HeroDef) string {
+		var ids []string
+		for _, def := range defs {
+			ids = append(ids, def.ID())
+		}
+		return fmt.Sprint(ids)
+	}
+
+	draft := RunDraft(ctx, DraftSequences[1], []lsha.Player{a, b}, ctx.HeroDefs())
+	if want := "[hero0 hero1]"; ids(draft.Banned()) != want {
+		t.Fatalf("banned = %s, want %s", ids(draft.Banned()), want)
+	}
+	if want := "[hero2 hero5]"; ids(draft.Picked(a)) != want {
+		t.Fatalf("picked by a = %s, want %s, banned heroes should leave the pool", ids(draft.Picked(a)), want)
+	}
+	if want := "[hero3 hero4]"; ids(draft.Picked(b)) != want {
+		t.Fatalf("picked by b = %s, want %s, banned heroes should leave the pool", ids(draft.Picked(b)), want)
+	}
+	if want := "[a:hero0:true b:hero1:true a:hero2:false b:hero3:false b:hero4:false a:hero5:false]"; fmt.Sprint(drafted) != want {
+		t.Fatalf("drafted = %v, want %s", drafted, want)
+	}
+}
@@ -2,6 +2,12 @@ module github.com/ohanan/LambdaSha/pkg/plugins/basic
 
 go 1.22.0
 
-require github.com/ohanan/LambdaSha/pkg/lsha v0.0.0
+require (
+	github.com/ohanan/LambdaSha v0.0.0
+	github.com/ohanan/LambdaSha/pkg/lsha v0.0.0
+)
 
-replace github.com/ohanan/LambdaSha/pkg/lsha => ../../lsha
+replace (
+	github.com/ohanan/LambdaSha => ../../..
+	github.com/ohanan/LambdaSha/pkg/lsha => ../../lsha
+)
@@ -3,8 +3,10 @@ package basic
 import "github.com/ohanan/LambdaSha/pkg/lsha"
 
 func Init(pb lsha.PluginBuilder) {
-	pb.Name(PluginName).Version(Version).Description("this is mode for one-on-one").
+	pb.Name(PluginName).Version(Version).Description("this is mode for one-on-one and team battles").
 		OnLoad(func(r lsha.ModeRepository) {
 			r.BuildMode(initOneOnOne)
+			r.BuildMode(initTwoVsTwo)
+			r.BuildMode(initThreeVsThree)
 		})
 }
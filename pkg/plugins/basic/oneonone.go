@@ -2,18 +2,124 @@ package basic
 
 import "github.com/ohanan/LambdaSha/pkg/lsha"
 
+const (
+	// FirstPlayerDrawPenalty is how many cards fewer the first player draws in the first turn.
+	FirstPlayerDrawPenalty = 1
+	// extraReserve is how many heroes more than the draft needs are put in the shared reserve.
+	extraReserve = 2
+	// dealtHeroes is how many heroes each player is dealt when the heroes are not drafted.
+	dealtHeroes = 3
+)
+
 func initOneOnOne(mb lsha.ModeBuilder) {
+	mb.Name(ModeOneOnOne)
 	mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
 		builder.MaxPlayer(2).MinPlayer(2)
+	}).OnCreateConfig(func(roomConfigBuilder lsha.ConfigBuilder) {
+		config := &oneOnOneConfig{draft: DraftSequences[0]}
+		roomConfigBuilder.BindData(config)
+		radio := roomConfigBuilder.Radio("选将方式", "双方依次从公共将池中禁用(B)和选择(P)武将，或随机发放武将").AddOption(DraftOff, "")
+		for _, sequence := range DraftSequences {
+			radio.AddOption(sequence, "")
+		}
+		radio.CheckOption(config.draft).OnCheckedOption(func(data any, name, radioName string) {
+			data.(*oneOnOneConfig).draft = radioName
+		})
+	}).HeroSelection(func(builder lsha.ModeHeroSelectionBuilder) {
+		builder.Selector(draftHeroes)
 	}).ModeRegistration(func(registration lsha.ModeRegistration) {
 
 	}).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
 		mode := &oneOnOne{}
+		InitRules(ctx)
+		initOneOnOneRules(ctx, mode)
 		for _, builder := range userBuilders {
 			builder.BindData(&oneOnOnePlayer{})
 		}
 		return mode
-	}).NextTurn(nextTurn)
+	}).NextTurn(NextTurn(func() BasicTurn { return &oneOnOneTurn{} }))
+}
+
+func initOneOnOneRules(ctx lsha.Context, mode *oneOnOne) {
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:first_player_draw",
+		eventName: lsha.EventDrawCountCalculating,
+		invoke: func(ctx lsha.Context) {
+			if mode.firstDrawn {
+				return
+			}
+			mode.firstDrawn = true
+			event := ctx.Event().(*lsha.DrawCountCalculatingEvent)
+			event.SetCount(max(event.Count()-FirstPlayerDrawPenalty, 0))
+		},
+	}, nil, lsha.EventDrawCountCalculating)
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:replace_hero",
+		eventName: lsha.EventDying,
+		// after everyone had the chance to rescue
+		priority: 1,
+		invoke: func(ctx lsha.Context) {
+			event := ctx.Event().(*lsha.DyingEvent)
+			player := event.Player()
+			data := lsha.Data[*oneOnOnePlayer](player)
+			if !player.IsAlive() || player.HP() > 0 || data == nil || len(data.reserve) == 0 {
+				return
+			}
+			replaceHero(ctx, player, data, event.Damage())
+		},
+	}, nil, lsha.EventDying)
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:game_result",
+		eventName: lsha.EventDeath,
+		invoke: func(ctx lsha.Context) {
+			// the death of a replaced hero does not end the game
+			if ctx.Event().(*lsha.DeathEvent).Player().IsAlive() {
+				return
+			}
+			var winners []lsha.Player
+			ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+				winners = append(winners, p)
+				return true
+			})
+			ctx.EndGame(winners...)
+		},
+	}, nil, lsha.EventDeath)
+}
+
+// replaceHero lets the dying player continue with a hero from the reserve instead of dying. The defeated
+// hero still dies: the death is invoked while the player is alive, then the player is cleaned up like a
+// killed one before the new hero is assigned.
+func replaceHero(ctx lsha.Context, player lsha.Player, data *oneOnOnePlayer, damage *lsha.Damage) {
+	def := ctx.AskHero(player, data.reserve)
+	for i, reserved := range data.reserve {
+		if reserved == def {
+			data.reserve = append(data.reserve[:i:i], data.reserve[i+1:]...)
+			break
+		}
+	}
+	death := &lsha.DeathEvent{}
+	death.SetPlayer(player)
+	death.SetDamage(damage)
+	ctx.Invoke(death)
+	var cards []lsha.Card
+	for _, zoneType := range []lsha.ZoneType{lsha.ZoneHand, lsha.ZoneEquip, lsha.ZoneJudge} {
+		cards = append(cards, player.Zone(zoneType).Cards()...)
+	}
+	for _, pile := range player.Piles() {
+		cards = append(cards, pile.Cards()...)
+	}
+	ctx.MoveCards(ctx.Zone(lsha.ZoneDiscardPile), lsha.MoveReasonDeath, cards...)
+	for _, effect := range player.Effects().List() {
+		ctx.RemoveEffect(player, effect.Effect().Name(), 0)
+	}
+	ctx.SetChained(player, false)
+	if hero := ctx.AssignHero(player, def); hero != nil {
+		event := &lsha.HeroSelectedEvent{}
+		event.SetPlayer(player)
+		event.SetHero(hero)
+		ctx.Invoke(event)
+	}
+	ctx.DrawCards(player, InitialHandCards)
 }
 
 type OneOnOneMode interface {
@@ -21,19 +127,89 @@ type OneOnOneMode interface {
 }
 
 type OneOnOnePlayer interface {
+	// Reserve lists the heroes left to replace the current hero.
+	Reserve() []lsha.HeroDef
 }
 type OneOnOneTurn interface {
 }
 type OneOnOnePhase interface {
 }
 type oneOnOne struct {
+	draft      *Draft
+	firstDrawn bool
+}
+
+type oneOnOneConfig struct {
+	draft string
+}
+
+// draftHeroes runs the ban/pick draft chosen in the room config on a shared reserve, or deals random heroes
+// if the draft is off, each player then picks the hero to play from its heroes and keeps the others as replacements.
+func draftHeroes(ctx lsha.Context) bool {
+	sequence := DraftSequences[0]
+	if config, ok := ctx.RoomConfig().(*oneOnOneConfig); ok && config.draft != "" {
+		sequence = config.draft
+	}
+	var players []lsha.Player
+	ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+		players = append(players, p)
+		return true
+	})
+	pool := ctx.HeroDefs()
+	ctx.Rand().Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	var draft *Draft
+	if sequence == DraftOff {
+		draft = DealHeroes(players, pool, dealtHeroes)
+	} else {
+		if size := draftSize(sequence) + extraReserve; len(pool) > size {
+			pool = pool[:size]
+		}
+		draft = RunDraft(ctx, sequence, players, pool)
+	}
+	if mode := lsha.Data[*oneOnOne](ctx); mode != nil {
+		mode.draft = draft
+	}
+	for _, player := range players {
+		picked := draft.Picked(player)
+		if len(picked) == 0 {
+			continue
+		}
+		def := ctx.AskHero(player, picked)
+		if data := lsha.Data[*oneOnOnePlayer](player); data != nil {
+			for _, reserved := range picked {
+				if reserved != def {
+					data.reserve = append(data.reserve, reserved)
+				}
+			}
+		}
+		if hero := ctx.AssignHero(player, def); hero != nil {
+			event := &lsha.HeroSelectedEvent{}
+			event.SetPlayer(player)
+			event.SetHero(hero)
+			ctx.Invoke(event)
+		}
+	}
+	return true
 }
 
 func (o *oneOnOne) Init() any {
 	return o
 }
 
+func (o *oneOnOne) Draft() *Draft {
+	return o.draft
+}
+
 type oneOnOnePlayer struct {
+	reserve []lsha.HeroDef
 }
+
+func (p *oneOnOnePlayer) Reserve() []lsha.HeroDef {
+	return p.reserve
+}
+
 type oneOnOneTurn struct {
+	Turn
 }
@@ -0,0 +1,105 @@
+package basic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// oneOnOneMode is the one-on-one mode with ten heroes of 4 hp.
+func oneOnOneMode(mb lsha.ModeBuilder) {
+	initOneOnOne(mb)
+	mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+		builder.MaxPlayer(2).MinPlayer(2).DisableRandomOrder()
+	}).ModeRegistration(func(registration lsha.ModeRegistration) {
+		for i := 0; i < 10; i++ {
+			registration.SetHeroDef(lsha.NewHeroDef(fmt.Sprint("hero", i), "", lsha.KingdomWei, lsha.GenderMale, 4))
+		}
+	})
+}
+
+func TestOneOnOneHeroes(t *testing.T) {
+	for _, draft := range []string{DraftOff, DraftSequences[0]} {
+		t.Run(draft, func(t *testing.T) {
+			ctx := runGame(oneOnOneMode, &oneOnOneConfig{draft: draft}, 0, nil, &testUser{id: "a"}, &testUser{id: "b"})
+			seen := map[lsha.HeroDef]struct{}{}
+			ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+				reserve := lsha.Data[*oneOnOnePlayer](p).Reserve()
+				if p.Hero() == nil || len(reserve) != 2 {
+					t.Fatalf("%s has hero %v and reserve %d, want a hero and 2 in reserve", p.User().ID(), p.Hero(), len(reserve))
+				}
+				for _, def := range append(reserve, p.Hero().Def()) {
+					seen[def] = struct{}{}
+				}
+				return true
+			})
+			if len(seen) != 6 {
+				t.Fatalf("heroes of the players should not overlap, got %d distinct", len(seen))
+			}
+		})
+	}
+}
+
+func TestFirstPlayerDrawPenalty(t *testing.T) {
+	ctx := runGame(oneOnOneMode, nil, 2, func(ctx lsha.Context) {
+		for i := 0; i < 10; i++ {
+			ctx.NewCard(CardSlash, lsha.CardTypeBasic, lsha.SuitSpade, i+1)
+		}
+	}, &testUser{id: "a"}, &testUser{id: "b"})
+	a, b := playerOf(ctx, "a"), playerOf(ctx, "b")
+	if got, want := a.Zone(lsha.ZoneHand).Len(), DefaultDrawCount-FirstPlayerDrawPenalty; got != want {
+		t.Fatalf("first player drew %d, want %d", got, want)
+	}
+	if got := b.Zone(lsha.ZoneHand).Len(); got != DefaultDrawCount {
+		t.Fatalf("second player drew %d, want %d", got, DefaultDrawCount)
+	}
+}
+
+func TestReplaceHero(t *testing.T) {
+	ctx := runGame(oneOnOneMode, nil, 0, nil, &testUser{id: "a"}, &testUser{id: "b"})
+	a, b := playerOf(ctx, "a"), playerOf(ctx, "b")
+	for i := 0; i < 20; i++ {
+		ctx.NewCard(CardSlash, lsha.CardTypeBasic, lsha.SuitSpade, i%13+1)
+	}
+	var deaths []string
+	ctx.AddTrigger(&trigger{name: "deaths", invoke: func(ctx lsha.Context) {
+		event := ctx.Event().(*lsha.DeathEvent)
+		deaths = append(deaths, fmt.Sprintf("%s:%v", event.Player().Hero().Def().ID(), event.Player().IsAlive()))
+	}}, nil, lsha.EventDeath)
+	discarded := 0
+	ctx.AddTrigger(&trigger{name: "discarded", invoke: func(ctx lsha.Context) {
+		discarded++
+	}}, nil, lsha.EventCardsDiscarded)
+	var winners []lsha.Player
+	ctx.AddTrigger(&trigger{name: "winners", invoke: func(ctx lsha.Context) {
+		winners = ctx.Event().(*lsha.GameOverEvent).Winners()
+	}}, nil, lsha.EventGameOver)
+
+	ctx.DrawCards(a, 2)
+	pile := ctx.NewPile(a, "pile", lsha.VisibilityPublic)
+	ctx.MoveCards(pile, lsha.MoveReasonDraw, ctx.DrawCards(a, 1)...)
+	ctx.AddEffect(a, lsha.NewEffect("effect"), 1, lsha.Permanent())
+	ctx.SetChained(a, true)
+	defeated := a.Hero().Def()
+	ctx.Damage(lsha.NewDamage(b, a, a.HP(), lsha.DamageNormal, nil))
+	if want := fmt.Sprintf("[%s:true]", defeated.ID()); fmt.Sprint(deaths) != want {
+		t.Fatalf("deaths = %v, want %s, the defeated hero should die while the player is alive", deaths, want)
+	}
+	if ctx.IsGameOver() || !a.IsAlive() || a.Hero().Def() == defeated || a.HP() != 4 {
+		t.Fatal("the player should continue with a hero from the reserve")
+	}
+	if len(lsha.Data[*oneOnOnePlayer](a).Reserve()) != 1 || a.Zone(lsha.ZoneHand).Len() != InitialHandCards {
+		t.Fatal("the replacement should be taken from the reserve and draw the initial hand")
+	}
+	if pile.Len() != 0 || discarded != 0 || a.Effects().Has("effect") || a.IsChained() {
+		t.Fatal("the player should be cleaned up like a killed one")
+	}
+
+	for i := 0; i < 2; i++ {
+		ctx.Damage(lsha.NewDamage(b, a, a.HP(), lsha.DamageNormal, nil))
+	}
+	if len(deaths) != 3 || a.IsAlive() || !ctx.IsGameOver() || len(winners) != 1 || winners[0] != b {
+		t.Fatalf("deaths %v, winners %v, the game should end when the reserve is used up", deaths, winners)
+	}
+}
@@ -0,0 +1,151 @@
+package basic
+
+import "github.com/ohanan/LambdaSha/pkg/lsha"
+
+const (
+	EventSlashLimitCalculating = "basic:slash_limit_calculating"
+
+	DefaultSlashLimit = 1
+	maxPlayActions    = 1000
+)
+
+type SlashLimitCalculatingEvent struct {
+	player lsha.Player
+	limit  int
+}
+
+func (e *SlashLimitCalculatingEvent) Name() string             { return EventSlashLimitCalculating }
+func (e *SlashLimitCalculatingEvent) StartPlayer() lsha.Player { return e.player }
+func (e *SlashLimitCalculatingEvent) Player() lsha.Player      { return e.player }
+func (e *SlashLimitCalculatingEvent) Limit() int               { return e.limit }
+func (e *SlashLimitCalculatingEvent) SetLimit(limit int)       { e.limit = limit }
+
+// SlashLimit is how many times player may use Slash in the play phase of a turn.
+func SlashLimit(ctx lsha.Context, player lsha.Player) int {
+	event := &SlashLimitCalculatingEvent{player: player, limit: DefaultSlashLimit}
+	ctx.Invoke(event)
+	return event.limit
+}
+
+// cardRule tells whether a card can be used in the play phase and normalizes its targets.
+type cardRule struct {
+	usable  func(ctx lsha.Context, player lsha.Player) bool
+	targets func(ctx lsha.Context, player lsha.Player, targets []lsha.Player) ([]lsha.Player, bool)
+}
+
+var cardRules = map[string]*cardRule{
+	CardSlash: {
+		usable: func(ctx lsha.Context, player lsha.Player) bool {
+			turn := CurrentTurn(ctx)
+			return turn == nil || turn.slashUsed < SlashLimit(ctx, player)
+		},
+		targets: func(ctx lsha.Context, player lsha.Player, targets []lsha.Player) ([]lsha.Player, bool) {
+			return targets, len(targets) == 1 && ctx.InAttackRange(player, targets[0])
+		},
+	},
+	CardPeach: {
+		usable:  func(ctx lsha.Context, player lsha.Player) bool { return player.HP() < player.MaxHP() },
+		targets: selfTarget,
+	},
+	CardIndulgence: {
+		targets: func(ctx lsha.Context, player lsha.Player, targets []lsha.Player) ([]lsha.Player, bool) {
+			return targets, len(targets) == 1 && targets[0] != player && targets[0].IsAlive() && !judging(targets[0], CardIndulgence)
+		},
+	},
+	CardSupplyShortage: {
+		targets: func(ctx lsha.Context, player lsha.Player, targets []lsha.Player) ([]lsha.Player, bool) {
+			return targets, len(targets) == 1 && targets[0] != player && ctx.Distance(player, targets[0]) <= 1 && !judging(targets[0], CardSupplyShortage)
+		},
+	},
+	CardLightning: {
+		targets: func(ctx lsha.Context, player lsha.Player, targets []lsha.Player) ([]lsha.Player, bool) {
+			return []lsha.Player{player}, !judging(player, CardLightning)
+		},
+	},
+}
+
+func selfTarget(ctx lsha.Context, player lsha.Player, targets []lsha.Player) ([]lsha.Player, bool) {
+	return []lsha.Player{player}, true
+}
+
+// judging tells whether a delayed trick named name is already placed before player.
+func judging(player lsha.Player, name string) bool {
+	for _, card := range player.Zone(lsha.ZoneJudge).Cards() {
+		if card.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleOf(ctx lsha.Context, card lsha.Card) *cardRule {
+	if rule, ok := cardRules[card.Name()]; ok {
+		return rule
+	}
+	if ctx.Equipment(card) != nil {
+		return &cardRule{targets: selfTarget}
+	}
+	return nil
+}
+
+func (s *PlayPhase) Run(ctx lsha.Context) {
+	player := ctx.Turn().Player()
+	usable := func(card lsha.Card) bool {
+		rule := ruleOf(ctx, card)
+		return rule != nil && (rule.usable == nil || rule.usable(ctx, player))
+	}
+	for i := 0; i < maxPlayActions && player.IsAlive() && !ctx.IsGameOver(); i++ {
+		choice := ctx.AskPlay(player, usable, lsha.Alive())
+		if choice == nil {
+			return
+		}
+		if choice.Skill != "" {
+			ctx.UseSkill(player, choice.Skill, choice.Cards, choice.Targets)
+			continue
+		}
+		card := choice.Card
+		if card == nil {
+			continue
+		}
+		targets, ok := ruleOf(ctx, card).targets(ctx, player, choice.Targets)
+		if !ok {
+			continue
+		}
+		if ctx.UseCard(player, card, lsha.Alive(), targets...) && card.Name() == CardSlash {
+			if turn := CurrentTurn(ctx); turn != nil {
+				turn.slashUsed++
+			}
+		}
+	}
+}
+
+func initPlay(ctx lsha.Context) {
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:slash",
+		eventName: lsha.EventCardUsed,
+		invoke: func(ctx lsha.Context) {
+			event := ctx.Event().(*lsha.CardUsedEvent)
+			if event.Card().Name() != CardSlash {
+				return
+			}
+			for _, target := range event.Targets() {
+				if ctx.RespondCard(target, event, lsha.CardNamed(CardDodge)) == nil {
+					ctx.Damage(lsha.NewDamage(event.Player(), target, 1, lsha.DamageNormal, event.Card()))
+				}
+			}
+		},
+	}, nil, lsha.EventCardUsed)
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:peach",
+		eventName: lsha.EventCardUsed,
+		invoke: func(ctx lsha.Context) {
+			event := ctx.Event().(*lsha.CardUsedEvent)
+			if event.Card().Name() != CardPeach {
+				return
+			}
+			for _, target := range event.Targets() {
+				ctx.Heal(lsha.NewHeal(event.Player(), target, 1, event.Card()))
+			}
+		},
+	}, nil, lsha.EventCardUsed)
+}
@@ -0,0 +1,80 @@
+package basic
+
+import (
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+func TestSlashLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		hp    int
+	}{
+		{name: "default limit", limit: DefaultSlashLimit, hp: 3},
+		{name: "raised limit", limit: 2, hp: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b lsha.Player
+			slash := func(player lsha.Player, request lsha.Request) any {
+				if r, ok := request.(*lsha.UseCardRequest); ok && len(r.Cards()) > 0 {
+					return &lsha.UseCardReply{Cards: r.Cards()[:1], Targets: []lsha.Player{b}}
+				}
+				return nil
+			}
+			runGame(plainMode, nil, 1, func(ctx lsha.Context) {
+				b = playerOf(ctx, "b")
+				ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+					ctx.SetMaxHP(p, 4)
+					ctx.SetHP(p, 4)
+					return true
+				})
+				for i := 0; i < 3; i++ {
+					ctx.NewCard(CardSlash, lsha.CardTypeBasic, lsha.SuitSpade, i+1)
+				}
+				ctx.DrawCards(playerOf(ctx, "a"), 3)
+				ctx.AddTrigger(&trigger{name: "limit", invoke: func(ctx lsha.Context) {
+					ctx.Event().(*SlashLimitCalculatingEvent).SetLimit(tt.limit)
+				}}, nil, EventSlashLimitCalculating)
+			}, &testUser{id: "a", reply: slash}, &testUser{id: "b"})
+			if b.HP() != tt.hp {
+				t.Fatalf("hp of b = %d, want %d", b.HP(), tt.hp)
+			}
+		})
+	}
+}
+
+func TestDuplicateDelayedTrick(t *testing.T) {
+	var b lsha.Player
+	asked := 0
+	indulge := func(player lsha.Player, request lsha.Request) any {
+		if r, ok := request.(*lsha.UseCardRequest); ok && len(r.Cards()) > 0 && asked < 2 {
+			asked++
+			return &lsha.UseCardReply{Cards: r.Cards()[:1], Targets: []lsha.Player{b}}
+		}
+		return nil
+	}
+	ctx := runGame(plainMode, nil, 1, func(ctx lsha.Context) {
+		b = playerOf(ctx, "b")
+		ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+			ctx.SetMaxHP(p, 4)
+			ctx.SetHP(p, 4)
+			return true
+		})
+		for i := 0; i < 2; i++ {
+			ctx.NewCard(CardIndulgence, lsha.CardTypeDelayedTrick, lsha.SuitHeart, i+1)
+		}
+		ctx.DrawCards(playerOf(ctx, "a"), 2)
+	}, &testUser{id: "a", reply: indulge}, &testUser{id: "b"})
+	if asked != 2 {
+		t.Fatalf("asked %d times to use Indulgence, want 2", asked)
+	}
+	if got := b.Zone(lsha.ZoneJudge).Len(); got != 1 {
+		t.Fatalf("b has %d delayed tricks, want 1", got)
+	}
+	if got := playerOf(ctx, "a").Zone(lsha.ZoneHand).Len(); got != 1 {
+		t.Fatalf("a has %d hand cards, the second Indulgence should stay in the hand", got)
+	}
+}
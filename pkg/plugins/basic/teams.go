@@ -0,0 +1,114 @@
+package basic
+
+import "github.com/ohanan/LambdaSha/pkg/lsha"
+
+const teamCount = 2
+
+func initTwoVsTwo(mb lsha.ModeBuilder) {
+	initTeamMode(mb, ModeTwoVsTwo, 2)
+}
+
+func initThreeVsThree(mb lsha.ModeBuilder) {
+	initTeamMode(mb, ModeThreeVsThree, 3)
+}
+
+func initTeamMode(mb lsha.ModeBuilder, name string, teamSize int) {
+	mb.Name(name)
+	mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+		builder.MinPlayer(teamSize * teamCount).MaxPlayer(teamSize * teamCount).Teams(teamCount)
+	}).HeroSelection(nil).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+		mode := &teamMode{}
+		InitRules(ctx)
+		initTeamRules(ctx, mode)
+		teams := assignTeams(ctx, userBuilders, teamSize)
+		// the teams sit interleaved, a random team takes the first seat
+		first := ctx.Rand().Intn(teamCount)
+		for i := 0; i < teamSize; i++ {
+			for j := 0; j < teamCount; j++ {
+				team := (first + j) % teamCount
+				teams[team][i].RewriteOrder(i*teamCount + j).RewriteTeam(team).BindData(&teamPlayer{})
+			}
+		}
+		return mode
+	}).NextTurn(NextTurn(func() BasicTurn { return &teamTurn{} }))
+}
+
+// assignTeams keeps the teams picked in the room and fills the teams with the users which did not pick one.
+func assignTeams(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder, teamSize int) [][]lsha.ModeInitUserBuilder {
+	teams := make([][]lsha.ModeInitUserBuilder, teamCount)
+	var unpicked []lsha.ModeInitUserBuilder
+	for _, builder := range userBuilders {
+		if team := builder.Team(); team >= 0 && team < teamCount && len(teams[team]) < teamSize {
+			teams[team] = append(teams[team], builder)
+		} else {
+			unpicked = append(unpicked, builder)
+		}
+	}
+	ctx.Rand().Shuffle(len(unpicked), func(i, j int) {
+		unpicked[i], unpicked[j] = unpicked[j], unpicked[i]
+	})
+	for team := range teams {
+		for len(teams[team]) < teamSize && len(unpicked) > 0 {
+			teams[team] = append(teams[team], unpicked[0])
+			unpicked = unpicked[1:]
+		}
+	}
+	return teams
+}
+
+func initTeamRules(ctx lsha.Context, mode *teamMode) {
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:team_seat",
+		eventName: lsha.EventPlayerPrepared,
+		invoke: func(ctx lsha.Context) {
+			player := ctx.Event().(*lsha.PlayerPreparedEvent).Player()
+			mode.players = append(mode.players, player)
+			// the teammates see the hand cards, everyone still knows how many there are
+			ctx.SetZoneVisibility(player.Zone(lsha.ZoneHand), lsha.VisibilityTeam, lsha.VisibilityPublic)
+		},
+	}, nil, lsha.EventPlayerPrepared)
+	ctx.AddTrigger(&trigger{
+		name:      "basic:trigger:team_result",
+		eventName: lsha.EventDeath,
+		invoke: func(ctx lsha.Context) {
+			if winners, over := mode.winners(); over {
+				ctx.EndGame(winners...)
+			}
+		},
+	}, nil, lsha.EventDeath)
+}
+
+type teamMode struct {
+	players []lsha.Player
+}
+
+// winners returns the whole team as winners when the players of the other teams are all dead.
+func (m *teamMode) winners() (winners []lsha.Player, over bool) {
+	alive := map[int]bool{}
+	for _, player := range m.players {
+		if player.IsAlive() {
+			alive[player.Team()] = true
+		}
+	}
+	if len(alive) > 1 {
+		return nil, false
+	}
+	for _, player := range m.players {
+		if alive[player.Team()] {
+			winners = append(winners, player)
+		}
+	}
+	return winners, true
+}
+
+// Teammates lists the other players in the team of player in seat order.
+func Teammates(ctx lsha.Context, player lsha.Player) []lsha.Player {
+	return lsha.Players(ctx, player, lsha.Teammate(player))
+}
+
+type teamPlayer struct {
+}
+
+type teamTurn struct {
+	Turn
+}
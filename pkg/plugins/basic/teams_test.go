@@ -0,0 +1,122 @@
+package basic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+// teamUser is a user which picked team in the room.
+type teamUser struct {
+	testUser
+	team int
+}
+
+func (u *teamUser) Team() int { return u.team }
+
+func teamUsers(teams ...int) []lsha.User {
+	users := make([]lsha.User, len(teams))
+	for i, team := range teams {
+		users[i] = &teamUser{testUser: testUser{id: string(rune('a' + i))}, team: team}
+	}
+	return users
+}
+
+func TestAssignTeams(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(lsha.ModeBuilder)
+		teams []int
+		// want is the team of the users whose team is decided by the picks
+		want map[string]int
+	}{
+		{name: "picked teams are kept", build: initTwoVsTwo, teams: []int{0, 1, 0, -1}, want: map[string]int{"a": 0, "b": 1, "c": 0, "d": 1}},
+		{name: "a full team overflows", build: initTwoVsTwo, teams: []int{0, 0, 0, 1}, want: map[string]int{"d": 1}},
+		{name: "an invalid team is ignored", build: initTwoVsTwo, teams: []int{5, 1, 1, -1}, want: map[string]int{"a": 0, "b": 1, "c": 1, "d": 0}},
+		{name: "nobody picked", build: initThreeVsThree, teams: []int{-1, -1, -1, -1, -1, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := runGame(tt.build, nil, 0, nil, teamUsers(tt.teams...)...)
+			var seats []lsha.Player
+			ctx.PlayerIter(nil)(func(p lsha.Player) bool {
+				seats = append(seats, p)
+				return true
+			})
+			if len(seats) != len(tt.teams) {
+				t.Fatalf("seated %d players, want %d", len(seats), len(tt.teams))
+			}
+			members := map[int][]string{}
+			for i, p := range seats {
+				if p.Order() != i || p.Team() != seats[i%teamCount].Team() || p.Team() == seats[(i+1)%len(seats)].Team() {
+					t.Fatalf("player %s of team %d sits at %d, the teams should sit interleaved", p.User().ID(), p.Team(), i)
+				}
+				members[p.Team()] = append(members[p.Team()], p.User().ID())
+			}
+			if len(members[0]) != len(seats)/teamCount || len(members[1]) != len(seats)/teamCount {
+				t.Fatalf("teams = %v, want teams of the same size", members)
+			}
+			for id, team := range tt.want {
+				if got := playerOf(ctx, id).Team(); got != team {
+					t.Fatalf("team of %s = %d, want %d", id, got, team)
+				}
+			}
+		})
+	}
+}
+
+func TestTeamVisibility(t *testing.T) {
+	ctx := runGame(initTwoVsTwo, nil, 0, nil, teamUsers(0, 0, 1, 1)...)
+	a, b, c := playerOf(ctx, "a"), playerOf(ctx, "b"), playerOf(ctx, "c")
+	if teammates := Teammates(ctx, a); len(teammates) != 1 || teammates[0] != b {
+		t.Fatalf("teammates of a = %v, want [b]", teammates)
+	}
+	if !lsha.Teammate(a)(b) || lsha.Teammate(a)(a) || lsha.Teammate(a)(c) || lsha.Teammate(a)(nil) {
+		t.Fatal("only the other players of the team should be teammates")
+	}
+	tests := []struct {
+		owner, viewer lsha.Player
+		want          bool
+	}{
+		{owner: a, viewer: a, want: true},
+		{owner: a, viewer: b, want: true},
+		{owner: a, viewer: c, want: false},
+		{owner: nil, viewer: a, want: false},
+	}
+	for _, tt := range tests {
+		if got := lsha.VisibilityTeam.VisibleTo(tt.owner, tt.viewer); got != tt.want {
+			t.Fatalf("VisibleTo(%v, %v) = %v, want %v", tt.owner, tt.viewer, got, tt.want)
+		}
+	}
+
+	hand := a.Zone(lsha.ZoneHand)
+	moved := &lsha.CardsMovedEvent{}
+	moved.SetFrom(b.Zone(lsha.ZoneHand))
+	moved.SetTo(hand)
+	if !moved.Visible(a) || !moved.Visible(b) || moved.Visible(c) || !moved.CountVisible(c) {
+		t.Fatal("only the team should see the cards given between the hands of the team, everyone should see how many")
+	}
+	if !lsha.ZoneVisibleTo(hand, b) || lsha.ZoneVisibleTo(hand, c) || !lsha.ZoneCountVisibleTo(hand, c) {
+		t.Fatal("only the team should see the hand cards, everyone should see how many cards are in the hand")
+	}
+}
+
+func TestTeamWinners(t *testing.T) {
+	ctx := runGame(initTwoVsTwo, nil, 0, nil, teamUsers(0, 0, 1, 1)...)
+	var winners []string
+	ctx.AddTrigger(&trigger{name: "winners", invoke: func(ctx lsha.Context) {
+		for _, winner := range ctx.Event().(*lsha.GameOverEvent).Winners() {
+			winners = append(winners, winner.User().ID())
+		}
+	}}, nil, lsha.EventGameOver)
+	ctx.Kill(playerOf(ctx, "a"), nil)
+	ctx.Kill(playerOf(ctx, "c"), nil)
+	if ctx.IsGameOver() {
+		t.Fatal("the game should go on while both teams have alive players")
+	}
+	ctx.Kill(playerOf(ctx, "d"), nil)
+	if !ctx.IsGameOver() || fmt.Sprint(winners) != "[a b]" && fmt.Sprint(winners) != "[b a]" {
+		t.Fatalf("winners = %v, want the whole team of a and b", winners)
+	}
+}
@@ -0,0 +1,25 @@
+package identity
+
+import "github.com/ohanan/LambdaSha/pkg/lsha"
+
+const (
+	PluginName   = "身份局"
+	ModeIdentity = "身份"
+	Version      = 1
+
+	MinPlayer = 5
+	MaxPlayer = 10
+
+	RebelKillReward = 3
+)
+
+const (
+	RoleLord     lsha.Role = "identity:role:lord"
+	RoleLoyalist lsha.Role = "identity:role:loyalist"
+	RoleRebel    lsha.Role = "identity:role:rebel"
+	RoleRenegade lsha.Role = "identity:role:renegade"
+)
+
+const (
+	EventRoleRevealed = "identity:role_revealed"
+)
@@ -0,0 +1,15 @@
+module github.com/ohanan/LambdaSha/pkg/plugins/identity
+
+go 1.22.0
+
+require (
+	github.com/ohanan/LambdaSha v0.0.0
+	github.com/ohanan/LambdaSha/pkg/lsha v0.0.0
+	github.com/ohanan/LambdaSha/pkg/plugins/basic v0.0.0
+)
+
+replace (
+	github.com/ohanan/LambdaSha => ../../..
+	github.com/ohanan/LambdaSha/pkg/lsha => ../../lsha
+	github.com/ohanan/LambdaSha/pkg/plugins/basic => ../basic
+)
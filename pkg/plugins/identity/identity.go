@@ -0,0 +1,201 @@
+package identity
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+	"github.com/ohanan/LambdaSha/pkg/plugins/basic"
+)
+
+// roleCounts are the loyalists, rebels and renegades besides the lord by player count.
+var roleCounts = map[int][3]int{
+	5:  {1, 2, 1},
+	6:  {1, 3, 1},
+	7:  {2, 3, 1},
+	8:  {2, 4, 1},
+	9:  {3, 4, 1},
+	10: {3, 4, 2},
+}
+
+func initIdentity(mb lsha.ModeBuilder) {
+	mb.Name(ModeIdentity).Description("主公与忠臣消灭反贼和内奸，反贼击杀主公，内奸成为最后的存活者")
+	mb.UserConfig(func(builder lsha.ModeUserConfigBuilder) {
+		builder.MinPlayer(MinPlayer).MaxPlayer(MaxPlayer)
+	}).HeroSelection(func(builder lsha.ModeHeroSelectionBuilder) {
+		builder.FirstPicker(func(ctx lsha.Context) lsha.Player {
+			return lsha.Data[*identity](ctx).lord
+		})
+	}).Init(func(ctx lsha.Context, userBuilders []lsha.ModeInitUserBuilder) (ctxData any) {
+		mode := &identity{}
+		basic.InitRules(ctx)
+		initRoles(ctx, mode)
+		roles := dealRoles(ctx, len(userBuilders))
+		lordSeat := 0
+		for i, role := range roles {
+			if role == RoleLord {
+				lordSeat = i
+			}
+		}
+		// the lord takes the first seat, the others keep their relative order
+		for i, builder := range userBuilders {
+			builder.Role(roles[i], roles[i] == RoleLord).
+				RewriteOrder((i - lordSeat + len(userBuilders)) % len(userBuilders)).
+				BindData(&identityPlayer{})
+		}
+		return mode
+	}).NextTurn(basic.NextTurn(func() basic.BasicTurn { return &identityTurn{} }))
+}
+
+func dealRoles(ctx lsha.Context, players int) []lsha.Role {
+	counts := roleCounts[min(max(players, MinPlayer), MaxPlayer)]
+	roles := []lsha.Role{RoleLord}
+	for i, role := range []lsha.Role{RoleLoyalist, RoleRebel, RoleRenegade} {
+		for j := 0; j < counts[i]; j++ {
+			roles = append(roles, role)
+		}
+	}
+	roles = roles[:min(len(roles), players)]
+	ctx.Rand().Shuffle(len(roles), func(i, j int) {
+		roles[i], roles[j] = roles[j], roles[i]
+	})
+	return roles
+}
+
+func initRoles(ctx lsha.Context, mode *identity) {
+	ctx.AddTrigger(&trigger{
+		name:      "identity:trigger:seat",
+		eventName: lsha.EventPlayerPrepared,
+		invoke: func(ctx lsha.Context) {
+			player := ctx.Event().(*lsha.PlayerPreparedEvent).Player()
+			mode.players = append(mode.players, player)
+			if player.IsLord() {
+				mode.lord = player
+			}
+		},
+	}, nil, lsha.EventPlayerPrepared)
+	ctx.AddTrigger(&trigger{
+		name:      "identity:trigger:lord_hp",
+		eventName: lsha.EventHeroSelected,
+		invoke: func(ctx lsha.Context) {
+			if player := ctx.Event().(*lsha.HeroSelectedEvent).Player(); player.IsLord() {
+				ctx.SetMaxHP(player, player.MaxHP()+1)
+				ctx.SetHP(player, player.MaxHP())
+			}
+		},
+	}, nil, lsha.EventHeroSelected)
+	ctx.AddTrigger(&trigger{
+		name:      "identity:trigger:reveal_lord",
+		eventName: lsha.EventGameStarted,
+		invoke: func(ctx lsha.Context) {
+			if mode.lord != nil {
+				revealRole(ctx, mode.lord)
+			}
+		},
+	}, nil, lsha.EventGameStarted)
+	ctx.AddTrigger(&trigger{
+		name:      "identity:trigger:death",
+		eventName: lsha.EventDeath,
+		invoke: func(ctx lsha.Context) {
+			event := ctx.Event().(*lsha.DeathEvent)
+			dead := event.Player()
+			revealRole(ctx, dead)
+			if winners, over := mode.winners(); over {
+				ctx.EndGame(winners...)
+				return
+			}
+			killer := event.Killer()
+			if killer == nil || !killer.IsAlive() {
+				return
+			}
+			switch {
+			case dead.Role() == RoleRebel:
+				ctx.DrawCards(killer, RebelKillReward)
+			case dead.Role() == RoleLoyalist && killer.IsLord():
+				var cards []lsha.Card
+				for _, zoneType := range []lsha.ZoneType{lsha.ZoneHand, lsha.ZoneEquip} {
+					cards = append(cards, killer.Zone(zoneType).Cards()...)
+				}
+				ctx.Discard(killer, cards...)
+			}
+		},
+	}, nil, lsha.EventDeath)
+}
+
+// revealRole makes the role of player visible to everyone, the roles are only visible to their owners until then.
+func revealRole(ctx lsha.Context, player lsha.Player) {
+	ctx.SetRoleVisibility(player, lsha.VisibilityPublic)
+	ctx.Invoke(&RoleRevealedEvent{player: player, role: player.Role()})
+}
+
+type RoleRevealedEvent struct {
+	player lsha.Player
+	role   lsha.Role
+}
+
+func (e *RoleRevealedEvent) Name() string             { return EventRoleRevealed }
+func (e *RoleRevealedEvent) StartPlayer() lsha.Player { return e.player }
+func (e *RoleRevealedEvent) Player() lsha.Player      { return e.player }
+func (e *RoleRevealedEvent) Role() lsha.Role          { return e.role }
+
+type identity struct {
+	players []lsha.Player
+	lord    lsha.Player
+}
+
+// winners checks the victory conditions after a death.
+func (m *identity) winners() (winners []lsha.Player, over bool) {
+	var alive []lsha.Player
+	var enemies int
+	for _, player := range m.players {
+		if !player.IsAlive() {
+			continue
+		}
+		alive = append(alive, player)
+		if role := player.Role(); role == RoleRebel || role == RoleRenegade {
+			enemies++
+		}
+	}
+	switch {
+	case m.lord != nil && !m.lord.IsAlive():
+		if len(alive) == 1 && alive[0].Role() == RoleRenegade {
+			return alive, true
+		}
+		return m.playersOf(RoleRebel), true
+	case enemies == 0:
+		return m.playersOf(RoleLord, RoleLoyalist), true
+	}
+	return nil, false
+}
+
+func (m *identity) playersOf(roles ...lsha.Role) []lsha.Player {
+	var players []lsha.Player
+	for _, player := range m.players {
+		for _, role := range roles {
+			if player.Role() == role {
+				players = append(players, player)
+			}
+		}
+	}
+	return players
+}
+
+type identityPlayer struct {
+}
+
+type identityTurn struct {
+	basic.Turn
+}
+
+type trigger struct {
+	name      string
+	eventName string
+	priority  float64
+	invoke    func(ctx lsha.Context)
+}
+
+func (t *trigger) Name() string      { return t.name }
+func (t *trigger) EventName() string { return t.eventName }
+func (t *trigger) Priority() float64 { return t.priority }
+func (t *trigger) Invoke(ctx lsha.Context, enter bool, result lsha.InvokeResult) {
+	if enter {
+		t.invoke(ctx)
+	}
+}
@@ -0,0 +1,130 @@
+package identity
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/ohanan/LambdaSha/pkg/core"
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+)
+
+type testUser struct {
+	id string
+}
+
+func (u *testUser) ID() string { return u.id }
+
+// runIdentity prepares an identity game for n players and returns it before the first turn.
+func runIdentity(n int) (lsha.Context, *identity) {
+	var game lsha.Context
+	users := make([]lsha.User, n)
+	for i := range users {
+		users[i] = &testUser{id: fmt.Sprint("u", i)}
+	}
+	core.BuildMode(func(mb lsha.ModeBuilder) {
+		initIdentity(mb)
+		mb.NextTurn(func(ctx lsha.Context, tb lsha.TurnBuilder) (turnData any) {
+			game = ctx
+			return nil
+		})
+	}).Run(nil, users)
+	return game, lsha.Data[*identity](game)
+}
+
+func TestDealRoles(t *testing.T) {
+	for players := MinPlayer; players <= MaxPlayer; players++ {
+		t.Run(fmt.Sprint(players), func(t *testing.T) {
+			ctx, mode := runIdentity(players)
+			counts := map[lsha.Role]int{}
+			for _, player := range mode.players {
+				counts[player.Role()]++
+			}
+			want := roleCounts[players]
+			if counts[RoleLord] != 1 || counts[RoleLoyalist] != want[0] || counts[RoleRebel] != want[1] || counts[RoleRenegade] != want[2] {
+				t.Fatalf("roles = %v, want 1 lord and %v", counts, want)
+			}
+			if mode.lord.Order() != 0 || !mode.lord.IsLord() || ctx.NextPlayer(nil) != mode.lord {
+				t.Fatal("the lord should take the first seat and start")
+			}
+			other := mode.players[1]
+			if !mode.lord.RoleVisibleTo(other) || other.RoleVisibleTo(mode.lord) || !other.RoleVisibleTo(other) {
+				t.Fatal("only the role of the lord should be visible to the others")
+			}
+		})
+	}
+}
+
+func TestDeaths(t *testing.T) {
+	lord := []lsha.Role{RoleLord}
+	tests := []struct {
+		name string
+		// deaths are killed in order, each by the player of killers at the same index, an empty role kills without a killer.
+		deaths   []lsha.Role
+		killers  []lsha.Role
+		winners  []lsha.Role
+		drawn    int
+		lordLost bool
+	}{
+		{name: "renegade survives the lord", deaths: []lsha.Role{RoleLoyalist, RoleRebel, RoleRebel, RoleLord}, killers: []lsha.Role{"", "", "", RoleRenegade}, winners: []lsha.Role{RoleRenegade}},
+		{name: "lord dies without rebels alive", deaths: []lsha.Role{RoleRebel, RoleRebel, RoleLord}, killers: []lsha.Role{"", "", RoleRenegade}, winners: []lsha.Role{RoleRebel, RoleRebel}},
+		{name: "lord dies with others alive", deaths: lord, killers: []lsha.Role{RoleRebel}, winners: []lsha.Role{RoleRebel, RoleRebel}},
+		{name: "enemies eliminated", deaths: []lsha.Role{RoleRebel, RoleRebel, RoleRenegade}, killers: []lsha.Role{"", "", ""}, winners: []lsha.Role{RoleLord, RoleLoyalist}},
+		{name: "rebel killed", deaths: []lsha.Role{RoleRebel}, killers: []lsha.Role{RoleLoyalist}, drawn: RebelKillReward},
+		{name: "lord kills loyalist", deaths: []lsha.Role{RoleLoyalist}, killers: lord, lordLost: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, mode := runIdentity(5)
+			var winners []lsha.Role
+			ctx.AddTrigger(&trigger{name: "winners", invoke: func(ctx lsha.Context) {
+				for _, winner := range ctx.Event().(*lsha.GameOverEvent).Winners() {
+					winners = append(winners, winner.Role())
+				}
+			}}, nil, lsha.EventGameOver)
+			for i := 0; i < 10; i++ {
+				ctx.NewCard("slash", lsha.CardTypeBasic, lsha.SuitSpade, i+1)
+			}
+			ctx.DrawCards(mode.lord, 2)
+			killed := map[lsha.Player]bool{}
+			pick := func(role lsha.Role) lsha.Player {
+				for _, player := range mode.playersOf(role) {
+					if !killed[player] {
+						return player
+					}
+				}
+				t.Fatalf("no alive %s", role)
+				return nil
+			}
+			var killer lsha.Player
+			hand := 0
+			for i, role := range tt.deaths {
+				dead := pick(role)
+				killer = nil
+				if tt.killers[i] != "" {
+					killer = pick(tt.killers[i])
+					hand = killer.Zone(lsha.ZoneHand).Len()
+				}
+				killed[dead] = true
+				ctx.Kill(dead, lsha.NewDamage(killer, dead, 1, lsha.DamageNormal, nil))
+				if !dead.RoleVisibleTo(mode.lord) {
+					t.Fatal("the role of a dead player should be revealed")
+				}
+			}
+			sort.Slice(winners, func(i, j int) bool { return winners[i] < winners[j] })
+			sort.Slice(tt.winners, func(i, j int) bool { return tt.winners[i] < tt.winners[j] })
+			if fmt.Sprint(winners) != fmt.Sprint(tt.winners) || ctx.IsGameOver() != (len(tt.winners) > 0) {
+				t.Fatalf("winners = %v, want %v", winners, tt.winners)
+			}
+			if tt.drawn > 0 && killer.Zone(lsha.ZoneHand).Len() != hand+tt.drawn {
+				t.Fatalf("killer has %d hand cards, want %d", killer.Zone(lsha.ZoneHand).Len(), hand+tt.drawn)
+			}
+			if tt.lordLost && mode.lord.Zone(lsha.ZoneHand).Len() != 0 {
+				t.Fatal("the lord should discard everything after killing a loyalist")
+			}
+			if !tt.lordLost && mode.lord.IsAlive() && mode.lord.Zone(lsha.ZoneHand).Len() != 2 {
+				t.Fatal("the lord should keep the hand cards")
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package identity
+
+import (
+	"github.com/ohanan/LambdaSha/pkg/lsha"
+	"github.com/ohanan/LambdaSha/pkg/plugins/basic"
+)
+
+func Init(pb lsha.PluginBuilder) {
+	pb.Name(PluginName).Version(Version).Description("this is mode for the classic identity game").
+		Dependencies(map[string]int{basic.PluginName: basic.Version}).
+		OnLoad(func(r lsha.ModeRepository) {
+			r.BuildMode(initIdentity)
+		})
+}